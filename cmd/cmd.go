@@ -20,6 +20,7 @@ func Run() error {
 		},
 		Commands: []*cli.Command{
 			apiCmd(cfg),
+			deadLetterCmd(cfg),
 		},
 		Flags: []cli.Flag{
 			&cli.StringFlag{
@@ -71,6 +72,13 @@ func Run() error {
 				Value:       "MY_SECRET_KEY", //
 				Destination: &cfg.Service.SecretKey,
 			},
+			&cli.StringFlag{
+				Name:        "internal-secret",
+				Category:    "crypto",
+				Usage:       "shared secret of the service tokens for the internal RPCs, e.g. CreateMeetingNA; required",
+				EnvVars:     []string{"INTERNAL_SECRET"},
+				Destination: &cfg.Service.InternalSecret,
+			},
 			&cli.StringFlag{
 				Name:        "pubsub",
 				Category:    "service/pubsub",
@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/webitel/wlog"
+
+	"github.com/webitel/web-meeting-backend/config"
+	"github.com/webitel/web-meeting-backend/infra/pubsub"
+	"github.com/webitel/web-meeting-backend/internal/handler"
+)
+
+// deadLetterCmd обслуговує події дзвінків, які не вдалося обробити
+func deadLetterCmd(cfg *config.Config) *cli.Command {
+	return &cli.Command{
+		Name:  "dead-letter",
+		Usage: "Manage call events which failed to process",
+		Subcommands: []*cli.Command{
+			{
+				Name:  "replay",
+				Usage: "Move dead-lettered call events back to the processing queue",
+				Flags: []cli.Flag{
+					&cli.IntFlag{
+						Name:  "limit",
+						Usage: "maximum events to replay, 0 replays all",
+						Value: 0,
+					},
+				},
+				Action: func(c *cli.Context) error {
+					log := wlog.NewLogger(&wlog.LoggerConfiguration{
+						EnableConsole: true,
+						ConsoleLevel:  cfg.Log.Lvl,
+					})
+
+					ps, err := pubsub.New(log, cfg.Pubsub.Address)
+					if err != nil {
+						return err
+					}
+					defer ps.Channel().Close()
+
+					n, err := handler.ReplayCallDeadLetters(c.Context, ps, c.Int("limit"))
+					log.Info(fmt.Sprintf("replayed %d call events from %s", n, handler.CallsDeadLetterQueue))
+
+					return err
+				},
+			},
+		},
+	}
+}
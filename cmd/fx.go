@@ -13,7 +13,13 @@ func NewApp(cfg *config.Config) *fx.App {
 		fx.Supply(cfg),
 		fx.Provide(ProvideContext),
 		fx.Provide(ProvidePubSub),
+		fx.Provide(ProvideBroker),
 		fx.Provide(ProvideEncrypter),
+		fx.Provide(ProvideOutcomeClassifier),
+		fx.Provide(ProvideCallVariables),
+		fx.Provide(ProvideChatNotices),
+		fx.Provide(ProvideMeetingQuota),
+		fx.Provide(ProvideGuestSigner),
 
 		// Infrastructure providers
 		fx.Provide(ProvideLogger),
@@ -24,8 +30,11 @@ func NewApp(cfg *config.Config) *fx.App {
 		fx.Provide(ProvideAuth),
 
 		// Адаптери для прив'язки інтерфейсів
+		fx.Provide(ProvideDatabase),
 		fx.Provide(ProvideMeetingStore),   // store.MeetingStoreImpl → service.MeetingStore
+		fx.Provide(ProvideWebhookStore),   // store.WebhookStoreImpl → service.WebhookStore
 		fx.Provide(ProvideMeetingService), // service.MeetingService → handler.MeetingService
+		fx.Provide(ProvideWebhookService), // service.WebhookService → handler.WebhookService
 
 		// Business logic modules
 		service.Module,
@@ -35,6 +44,7 @@ func NewApp(cfg *config.Config) *fx.App {
 		fx.Invoke(StartGrpcServer),
 		fx.Invoke(RegisterHandlers),
 		fx.Invoke(EnsureCluster),
+		fx.Invoke(StartChatCloser),
 
 		// fx налаштування
 		fx.NopLogger, // Вимикаємо fx логи, використовуємо наш logger
@@ -3,6 +3,8 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"os"
+	"strings"
 
 	"go.uber.org/fx"
 
@@ -16,6 +18,7 @@ import (
 	"github.com/webitel/web-meeting-backend/infra/engine"
 	"github.com/webitel/web-meeting-backend/infra/grpc_srv"
 	"github.com/webitel/web-meeting-backend/infra/pubsub"
+	"github.com/webitel/web-meeting-backend/infra/sql"
 	"github.com/webitel/web-meeting-backend/infra/sql/pgsql"
 	"github.com/webitel/web-meeting-backend/internal/handler"
 	"github.com/webitel/web-meeting-backend/internal/model"
@@ -58,7 +61,8 @@ func ProvideLogger(cfg *config.Config, lc fx.Lifecycle) (*wlog.Logger, error) {
 }
 
 func ProvideGrpcServer(cfg *config.Config, l *wlog.Logger, am auth.Manager, lc fx.Lifecycle) (*grpc_srv.Server, error) {
-	s, err := grpc_srv.New(cfg.Service.Address, l, am)
+	// без спільного секрету сервіс не стартує, щоб внутрішні RPC не відхилялися непомітно
+	s, err := grpc_srv.New(cfg.Service.Address, l, am, auth.NewServiceSigner(cfg.Service.InternalSecret), handler.AccessPolicy)
 	if err != nil {
 		return nil, err
 	}
@@ -76,8 +80,10 @@ func ProvideGrpcServer(cfg *config.Config, l *wlog.Logger, am auth.Manager, lc f
 	return s, nil
 }
 
-func ProvideCluster(cfg *config.Config, srv *grpc_srv.Server, l *wlog.Logger, lc fx.Lifecycle) (*consul.Cluster, error) {
+func ProvideCluster(cfg *config.Config, srv *grpc_srv.Server, ps *pubsub.Manager, l *wlog.Logger, lc fx.Lifecycle) (*consul.Cluster, error) {
 	c := consul.NewCluster(model.ServiceName, cfg.Service.Consul, l)
+	// сервіс нездоровий, поки підписки не споживають події
+	c.SetCheck(ps.Healthy)
 	host := srv.Host()
 
 	lc.Append(fx.Hook{
@@ -104,15 +110,20 @@ func ProvidePubSub(cfg *config.Config, l *wlog.Logger, lc fx.Lifecycle) (*pubsub
 			return ps.Start()
 		},
 		OnStop: func(ctx context.Context) error {
-			return ps.Shutdown()
+			return ps.Shutdown(ctx)
 		},
 	})
 
 	return ps, nil
 }
 
+// ProvideBroker прив'язує RabbitMQ менеджер до інтерфейсу брокера
+func ProvideBroker(ps *pubsub.Manager) pubsub.Broker {
+	return ps
+}
+
 func ProvideAuth(cfg *config.Config, l *wlog.Logger, lc fx.Lifecycle) (auth.Manager, error) {
-	a := auth.NewAuthManager(1000, 15, cfg.Service.Consul, l)
+	a := auth.NewAuthManager(cfg.Auth.CacheSize, cfg.Auth.CacheTTL, cfg.Auth.DeniedTTL, cfg.Service.Consul, l)
 
 	lc.Append(fx.Hook{
 		OnStart: func(ctx context.Context) error {
@@ -166,38 +177,115 @@ func ProvideEngine(cfg *config.Config, l *wlog.Logger, lc fx.Lifecycle) (*engine
 	return cli, nil
 }
 
+// ProvideOutcomeClassifier створює класифікатор причин завершення дзвінка з перевизначеннями з конфігурації
+func ProvideOutcomeClassifier(cfg *config.Config) (*model.OutcomeClassifier, error) {
+	overrides, err := model.ParseCauseOutcomes(cfg.Meeting.CauseOutcomes)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.NewOutcomeClassifier(overrides), nil
+}
+
+// ProvideCallVariables створює перелік змінних зустрічі, що передаються в дзвінки
+func ProvideCallVariables(cfg *config.Config) *model.CallVariables {
+	return model.NewCallVariables(cfg.Meeting.CallVariables)
+}
+
+// ProvideMeetingQuota створює ліцензію та ліміти зустрічей домену з конфігурації
+func ProvideMeetingQuota(cfg *config.Config) (*model.MeetingQuota, error) {
+	if cfg.Meeting.MaxActive < 0 || cfg.Meeting.MaxDaily < 0 {
+		return nil, fmt.Errorf("meeting limits must not be negative")
+	}
+
+	return &model.MeetingQuota{
+		License:   strings.ToUpper(strings.TrimSpace(cfg.Meeting.License)),
+		MaxActive: cfg.Meeting.MaxActive,
+		MaxDaily:  cfg.Meeting.MaxDaily,
+	}, nil
+}
+
+// ProvideGuestSigner створює підпис облікових даних учасників зустрічі ключем, похідним від ключа сервісу
+func ProvideGuestSigner(cfg *config.Config) *auth.GuestSigner {
+	return auth.NewGuestSigner(cfg.Service.SecretKey, cfg.Meeting.GuestTTL)
+}
+
+// ProvideChatNotices створює повідомлення про події зустрічі для чату з шаблонами з конфігурації
+func ProvideChatNotices(cfg *config.Config) (*model.ChatNotices, error) {
+	var templates map[string]map[model.ChatNotice]string
+
+	if file := cfg.Meeting.ChatNoticeTemplates; file != "" {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read chat notice templates: %w", err)
+		}
+
+		if templates, err = model.ParseChatNoticeTemplates(data); err != nil {
+			return nil, err
+		}
+	}
+
+	return model.NewChatNotices(cfg.Meeting.ChatNotices, cfg.Meeting.ChatNoticeLanguage, templates)
+}
+
 func ProvideContext() context.Context {
 	return context.Background()
 }
 
-// ProvideMeetingStore створює сховище (SQL або Memory) в залежності від конфігурації
-func ProvideMeetingStore(
+// ProvideDatabase відкриває підключення до PostgreSQL
+func ProvideDatabase(
 	ctx context.Context,
 	cfg *config.Config,
 	log *wlog.Logger,
 	lc fx.Lifecycle,
-) (service.MeetingStore, error) {
-	log.Info("Using SQL Meeting Store (PostgreSQL)")
-
+) (sql.Store, error) {
 	db, err := pgsql.New(ctx, cfg.SqlSettings.DSN, log)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 
+	lc.Append(fx.Hook{
+		OnStop: func(ctx context.Context) error {
+			log.Debug("Closing database connection")
+			return db.Close()
+		},
+	})
+
+	return db, nil
+}
+
+// ProvideMeetingStore створює сховище (SQL або Memory) в залежності від конфігурації
+func ProvideMeetingStore(
+	ctx context.Context,
+	db sql.Store,
+	log *wlog.Logger,
+	lc fx.Lifecycle,
+) service.MeetingStore {
+	log.Info("Using SQL Meeting Store (PostgreSQL)")
+
 	cancelCtx, cancel := context.WithCancel(ctx)
 
 	lc.Append(fx.Hook{
 		OnStop: func(ctx context.Context) error {
 			cancel()
-			log.Debug("Closing database connection")
-			return db.Close()
+			return nil
 		},
 	})
 
-	return sqlStore.NewMeetingStore(cancelCtx, db, log), nil
+	return sqlStore.NewMeetingStore(cancelCtx, db, log)
+}
+
+// ProvideWebhookStore - адаптер для прив'язки sql.WebhookStoreImpl → service.WebhookStore
+func ProvideWebhookStore(db sql.Store, log *wlog.Logger) service.WebhookStore {
+	return sqlStore.NewWebhookStore(db, log)
 }
 
 // ProvideMeetingService - адаптер для прив'язки service.MeetingService → handler.MeetingService
 func ProvideMeetingService(impl *service.MeetingService) handler.MeetingService {
 	return impl
 }
+
+// ProvideWebhookService - адаптер для прив'язки service.WebhookService → handler.WebhookService
+func ProvideWebhookService(impl *service.WebhookService) handler.WebhookService {
+	return impl
+}
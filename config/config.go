@@ -1,14 +1,40 @@
 package config
 
+import "time"
+
 type Config struct {
 	Service     Service
 	Log         LogSettings
 	SqlSettings SqlSettings
 	Pubsub      Pubsub
+	Webhook     Webhook
+	Meeting     Meeting
+	Auth        Auth
+}
+
+type Auth struct {
+	// CacheSize limits the cached sessions.
+	CacheSize int
+	// CacheTTL is how long the session is cached.
+	CacheTTL time.Duration
+	// DeniedTTL is how long the invalid token is denied without asking the auth service, 0 disables it.
+	DeniedTTL time.Duration
+	// EventsExchange is the topic exchange of the session events which evict the cached sessions.
+	EventsExchange string
+	// EventsKey is the routing key of the session events.
+	EventsKey string
+	// EventsTokenField is the JSON field of the session event with the ended session token.
+	EventsTokenField string
+	// EventsUserField is the JSON field of the session event with the user whose sessions are ended.
+	EventsUserField string
 }
 
 type Pubsub struct {
-	Address string
+	Address       string
+	DeliveryLimit int
+	RetryDelay    time.Duration
+	Prefetch      int
+	Workers       int
 }
 
 type SqlSettings struct {
@@ -20,6 +46,8 @@ type Service struct {
 	Address   string
 	Consul    string
 	SecretKey string
+	// InternalSecret signs the service tokens of the internal callers.
+	InternalSecret string
 }
 
 type LogSettings struct {
@@ -29,3 +57,33 @@ type LogSettings struct {
 	File    string
 	Console bool
 }
+
+type Webhook struct {
+	Timeout          time.Duration
+	MaxAttempts      int
+	FailureThreshold int
+	// AllowPrivate allows the receivers in the loopback, private and link-local networks.
+	AllowPrivate bool
+}
+
+type Meeting struct {
+	// CauseOutcomes overrides the hangup cause classification, "CAUSE=outcome" pairs separated by commas.
+	CauseOutcomes string
+	// CallVariables lists the meeting variables pushed into the linked calls, "*" for all of them;
+	// none are pushed when empty, as the variables may carry secrets.
+	CallVariables string
+	// ChatNotices lists the meeting events posted into the linked chat, "*" for all of them.
+	ChatNotices string
+	// ChatNoticeLanguage is the notice language of the meetings without the language variable.
+	ChatNoticeLanguage string
+	// ChatNoticeTemplates is the JSON file with the notice texts by language and notice.
+	ChatNoticeTemplates string
+	// License is the product license required to create meetings, empty when none is required.
+	License string
+	// MaxActive limits the active meetings of a domain, 0 for no limit.
+	MaxActive int
+	// MaxDaily limits the meetings created by a domain per day, 0 for no limit.
+	MaxDaily int
+	// GuestTTL is the lifetime of the participant credential.
+	GuestTTL time.Duration
+}
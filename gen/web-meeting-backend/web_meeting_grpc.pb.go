@@ -19,12 +19,30 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	MeetingService_CreateMeeting_FullMethodName       = "/web_meeting_backend.MeetingService/CreateMeeting"
-	MeetingService_CreateMeetingNA_FullMethodName     = "/web_meeting_backend.MeetingService/CreateMeetingNA"
-	MeetingService_GetMeetingView_FullMethodName      = "/web_meeting_backend.MeetingService/GetMeetingView"
-	MeetingService_GetMeeting_FullMethodName          = "/web_meeting_backend.MeetingService/GetMeeting"
-	MeetingService_DeleteMeeting_FullMethodName       = "/web_meeting_backend.MeetingService/DeleteMeeting"
-	MeetingService_SatisfactionMeeting_FullMethodName = "/web_meeting_backend.MeetingService/SatisfactionMeeting"
+	MeetingService_CreateMeeting_FullMethodName             = "/web_meeting_backend.MeetingService/CreateMeeting"
+	MeetingService_CreateMeetingNA_FullMethodName           = "/web_meeting_backend.MeetingService/CreateMeetingNA"
+	MeetingService_GetMeetingView_FullMethodName            = "/web_meeting_backend.MeetingService/GetMeetingView"
+	MeetingService_JoinMeeting_FullMethodName               = "/web_meeting_backend.MeetingService/JoinMeeting"
+	MeetingService_RevokeMeetingParticipant_FullMethodName  = "/web_meeting_backend.MeetingService/RevokeMeetingParticipant"
+	MeetingService_GetMeeting_FullMethodName                = "/web_meeting_backend.MeetingService/GetMeeting"
+	MeetingService_GetMeetingDetails_FullMethodName         = "/web_meeting_backend.MeetingService/GetMeetingDetails"
+	MeetingService_DeleteMeeting_FullMethodName             = "/web_meeting_backend.MeetingService/DeleteMeeting"
+	MeetingService_StartMeetingCall_FullMethodName          = "/web_meeting_backend.MeetingService/StartMeetingCall"
+	MeetingService_EndMeeting_FullMethodName                = "/web_meeting_backend.MeetingService/EndMeeting"
+	MeetingService_HoldMeetingCall_FullMethodName           = "/web_meeting_backend.MeetingService/HoldMeetingCall"
+	MeetingService_UnHoldMeetingCall_FullMethodName         = "/web_meeting_backend.MeetingService/UnHoldMeetingCall"
+	MeetingService_BlindTransferMeetingCall_FullMethodName  = "/web_meeting_backend.MeetingService/BlindTransferMeetingCall"
+	MeetingService_DtmfMeetingCall_FullMethodName           = "/web_meeting_backend.MeetingService/DtmfMeetingCall"
+	MeetingService_LinkMeetingContact_FullMethodName        = "/web_meeting_backend.MeetingService/LinkMeetingContact"
+	MeetingService_LinkMeetingConversationNA_FullMethodName = "/web_meeting_backend.MeetingService/LinkMeetingConversationNA"
+	MeetingService_SendMeetingMessage_FullMethodName        = "/web_meeting_backend.MeetingService/SendMeetingMessage"
+	MeetingService_SearchMeetingMessages_FullMethodName     = "/web_meeting_backend.MeetingService/SearchMeetingMessages"
+	MeetingService_GetMeetingTranscript_FullMethodName      = "/web_meeting_backend.MeetingService/GetMeetingTranscript"
+	MeetingService_ExportMeetingTranscript_FullMethodName   = "/web_meeting_backend.MeetingService/ExportMeetingTranscript"
+	MeetingService_GetMeetingChatPolicy_FullMethodName      = "/web_meeting_backend.MeetingService/GetMeetingChatPolicy"
+	MeetingService_UpdateMeetingChatPolicy_FullMethodName   = "/web_meeting_backend.MeetingService/UpdateMeetingChatPolicy"
+	MeetingService_GetMeetingUsage_FullMethodName           = "/web_meeting_backend.MeetingService/GetMeetingUsage"
+	MeetingService_SatisfactionMeeting_FullMethodName       = "/web_meeting_backend.MeetingService/SatisfactionMeeting"
 )
 
 // MeetingServiceClient is the client API for MeetingService service.
@@ -37,10 +55,50 @@ type MeetingServiceClient interface {
 	CreateMeetingNA(ctx context.Context, in *CreateMeetingRequest, opts ...grpc.CallOption) (*CreateMeetingResponse, error)
 	// GetMeetingView retrieves public-facing information about a meeting.
 	GetMeetingView(ctx context.Context, in *GetMeetingRequest, opts ...grpc.CallOption) (*MeetingView, error)
+	// JoinMeeting issues the short-lived credential of the meeting participant, sent in the x-webitel-guest
+	// header of the participant actions. The valid credential in the header is renewed for the same participant.
+	JoinMeeting(ctx context.Context, in *JoinMeetingRequest, opts ...grpc.CallOption) (*GuestCredential, error)
+	// RevokeMeetingParticipant stops accepting the credentials of the meeting participant.
+	RevokeMeetingParticipant(ctx context.Context, in *RevokeMeetingParticipantRequest, opts ...grpc.CallOption) (*RevokeMeetingParticipantResponse, error)
 	// GetMeeting retrieves the full meeting data object.
 	GetMeeting(ctx context.Context, in *GetMeetingRequest, opts ...grpc.CallOption) (*Meeting, error)
+	// GetMeetingDetails retrieves the meeting with the engine details of its calls.
+	// Recordings are returned only with the playback_record_file permission.
+	GetMeetingDetails(ctx context.Context, in *GetMeetingRequest, opts ...grpc.CallOption) (*MeetingDetails, error)
 	// DeleteMeeting removes an existing meeting by its unique identifier.
 	DeleteMeeting(ctx context.Context, in *DeleteMeetingRequest, opts ...grpc.CallOption) (*DeleteMeetingResponse, error)
+	// StartMeetingCall originates the call to the user, queue or number from the meeting variables.
+	StartMeetingCall(ctx context.Context, in *StartMeetingCallRequest, opts ...grpc.CallOption) (*StartMeetingCallResponse, error)
+	// EndMeeting hangs up the active meeting calls, closes the meeting chat and ends the meeting.
+	EndMeeting(ctx context.Context, in *EndMeetingRequest, opts ...grpc.CallOption) (*EndMeetingResponse, error)
+	// HoldMeetingCall puts the current meeting call on hold.
+	HoldMeetingCall(ctx context.Context, in *MeetingCallRequest, opts ...grpc.CallOption) (*MeetingCallResponse, error)
+	// UnHoldMeetingCall resumes the current meeting call.
+	UnHoldMeetingCall(ctx context.Context, in *MeetingCallRequest, opts ...grpc.CallOption) (*MeetingCallResponse, error)
+	// BlindTransferMeetingCall transfers the current meeting call to the destination.
+	BlindTransferMeetingCall(ctx context.Context, in *BlindTransferMeetingCallRequest, opts ...grpc.CallOption) (*MeetingCallResponse, error)
+	// DtmfMeetingCall sends DTMF digits to the current meeting call.
+	DtmfMeetingCall(ctx context.Context, in *DtmfMeetingCallRequest, opts ...grpc.CallOption) (*MeetingCallResponse, error)
+	// LinkMeetingContact links the contact to the meeting conversation, or creates one from the conversation
+	// and the meeting variables (name, phone, email) when no contact id is given.
+	LinkMeetingContact(ctx context.Context, in *LinkMeetingContactRequest, opts ...grpc.CallOption) (*LinkMeetingContactResponse, error)
+	// LinkMeetingConversationNA is an internal method which records the conversation the chat widget started
+	// for a meeting created without the chat options, so the conversation is closed with the meeting.
+	LinkMeetingConversationNA(ctx context.Context, in *LinkMeetingConversationRequest, opts ...grpc.CallOption) (*LinkMeetingConversationResponse, error)
+	// SendMeetingMessage sends the customer text message to the meeting chat.
+	SendMeetingMessage(ctx context.Context, in *SendMeetingMessageRequest, opts ...grpc.CallOption) (*MeetingMessage, error)
+	// SearchMeetingMessages lists the meeting chat messages, newest first.
+	SearchMeetingMessages(ctx context.Context, in *SearchMeetingMessagesRequest, opts ...grpc.CallOption) (*ListMeetingMessage, error)
+	// GetMeetingTranscript lists the meeting chat messages for the agents, newest first.
+	GetMeetingTranscript(ctx context.Context, in *GetMeetingTranscriptRequest, opts ...grpc.CallOption) (*MeetingTranscript, error)
+	// ExportMeetingTranscript renders the meeting with its chat messages as a text or JSON file.
+	ExportMeetingTranscript(ctx context.Context, in *ExportMeetingTranscriptRequest, opts ...grpc.CallOption) (*MeetingTranscriptFile, error)
+	// GetMeetingChatPolicy returns what happens to the meeting chat of the domain once the agent call ends.
+	GetMeetingChatPolicy(ctx context.Context, in *GetMeetingChatPolicyRequest, opts ...grpc.CallOption) (*MeetingChatPolicy, error)
+	// UpdateMeetingChatPolicy sets what happens to the meeting chat of the domain once the agent call ends.
+	UpdateMeetingChatPolicy(ctx context.Context, in *UpdateMeetingChatPolicyRequest, opts ...grpc.CallOption) (*MeetingChatPolicy, error)
+	// GetMeetingUsage returns the meetings of the caller domain against the quota.
+	GetMeetingUsage(ctx context.Context, in *GetMeetingUsageRequest, opts ...grpc.CallOption) (*MeetingUsage, error)
 	// SatisfactionMeeting submits feedback or a satisfaction rating for a completed meeting.
 	SatisfactionMeeting(ctx context.Context, in *SatisfactionMeetingRequest, opts ...grpc.CallOption) (*SatisfactionMeetingResponse, error)
 }
@@ -80,6 +138,24 @@ func (c *meetingServiceClient) GetMeetingView(ctx context.Context, in *GetMeetin
 	return out, nil
 }
 
+func (c *meetingServiceClient) JoinMeeting(ctx context.Context, in *JoinMeetingRequest, opts ...grpc.CallOption) (*GuestCredential, error) {
+	out := new(GuestCredential)
+	err := c.cc.Invoke(ctx, MeetingService_JoinMeeting_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *meetingServiceClient) RevokeMeetingParticipant(ctx context.Context, in *RevokeMeetingParticipantRequest, opts ...grpc.CallOption) (*RevokeMeetingParticipantResponse, error) {
+	out := new(RevokeMeetingParticipantResponse)
+	err := c.cc.Invoke(ctx, MeetingService_RevokeMeetingParticipant_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *meetingServiceClient) GetMeeting(ctx context.Context, in *GetMeetingRequest, opts ...grpc.CallOption) (*Meeting, error) {
 	out := new(Meeting)
 	err := c.cc.Invoke(ctx, MeetingService_GetMeeting_FullMethodName, in, out, opts...)
@@ -89,6 +165,15 @@ func (c *meetingServiceClient) GetMeeting(ctx context.Context, in *GetMeetingReq
 	return out, nil
 }
 
+func (c *meetingServiceClient) GetMeetingDetails(ctx context.Context, in *GetMeetingRequest, opts ...grpc.CallOption) (*MeetingDetails, error) {
+	out := new(MeetingDetails)
+	err := c.cc.Invoke(ctx, MeetingService_GetMeetingDetails_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *meetingServiceClient) DeleteMeeting(ctx context.Context, in *DeleteMeetingRequest, opts ...grpc.CallOption) (*DeleteMeetingResponse, error) {
 	out := new(DeleteMeetingResponse)
 	err := c.cc.Invoke(ctx, MeetingService_DeleteMeeting_FullMethodName, in, out, opts...)
@@ -98,6 +183,141 @@ func (c *meetingServiceClient) DeleteMeeting(ctx context.Context, in *DeleteMeet
 	return out, nil
 }
 
+func (c *meetingServiceClient) StartMeetingCall(ctx context.Context, in *StartMeetingCallRequest, opts ...grpc.CallOption) (*StartMeetingCallResponse, error) {
+	out := new(StartMeetingCallResponse)
+	err := c.cc.Invoke(ctx, MeetingService_StartMeetingCall_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *meetingServiceClient) EndMeeting(ctx context.Context, in *EndMeetingRequest, opts ...grpc.CallOption) (*EndMeetingResponse, error) {
+	out := new(EndMeetingResponse)
+	err := c.cc.Invoke(ctx, MeetingService_EndMeeting_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *meetingServiceClient) HoldMeetingCall(ctx context.Context, in *MeetingCallRequest, opts ...grpc.CallOption) (*MeetingCallResponse, error) {
+	out := new(MeetingCallResponse)
+	err := c.cc.Invoke(ctx, MeetingService_HoldMeetingCall_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *meetingServiceClient) UnHoldMeetingCall(ctx context.Context, in *MeetingCallRequest, opts ...grpc.CallOption) (*MeetingCallResponse, error) {
+	out := new(MeetingCallResponse)
+	err := c.cc.Invoke(ctx, MeetingService_UnHoldMeetingCall_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *meetingServiceClient) BlindTransferMeetingCall(ctx context.Context, in *BlindTransferMeetingCallRequest, opts ...grpc.CallOption) (*MeetingCallResponse, error) {
+	out := new(MeetingCallResponse)
+	err := c.cc.Invoke(ctx, MeetingService_BlindTransferMeetingCall_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *meetingServiceClient) DtmfMeetingCall(ctx context.Context, in *DtmfMeetingCallRequest, opts ...grpc.CallOption) (*MeetingCallResponse, error) {
+	out := new(MeetingCallResponse)
+	err := c.cc.Invoke(ctx, MeetingService_DtmfMeetingCall_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *meetingServiceClient) LinkMeetingContact(ctx context.Context, in *LinkMeetingContactRequest, opts ...grpc.CallOption) (*LinkMeetingContactResponse, error) {
+	out := new(LinkMeetingContactResponse)
+	err := c.cc.Invoke(ctx, MeetingService_LinkMeetingContact_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *meetingServiceClient) LinkMeetingConversationNA(ctx context.Context, in *LinkMeetingConversationRequest, opts ...grpc.CallOption) (*LinkMeetingConversationResponse, error) {
+	out := new(LinkMeetingConversationResponse)
+	err := c.cc.Invoke(ctx, MeetingService_LinkMeetingConversationNA_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *meetingServiceClient) SendMeetingMessage(ctx context.Context, in *SendMeetingMessageRequest, opts ...grpc.CallOption) (*MeetingMessage, error) {
+	out := new(MeetingMessage)
+	err := c.cc.Invoke(ctx, MeetingService_SendMeetingMessage_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *meetingServiceClient) SearchMeetingMessages(ctx context.Context, in *SearchMeetingMessagesRequest, opts ...grpc.CallOption) (*ListMeetingMessage, error) {
+	out := new(ListMeetingMessage)
+	err := c.cc.Invoke(ctx, MeetingService_SearchMeetingMessages_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *meetingServiceClient) GetMeetingTranscript(ctx context.Context, in *GetMeetingTranscriptRequest, opts ...grpc.CallOption) (*MeetingTranscript, error) {
+	out := new(MeetingTranscript)
+	err := c.cc.Invoke(ctx, MeetingService_GetMeetingTranscript_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *meetingServiceClient) ExportMeetingTranscript(ctx context.Context, in *ExportMeetingTranscriptRequest, opts ...grpc.CallOption) (*MeetingTranscriptFile, error) {
+	out := new(MeetingTranscriptFile)
+	err := c.cc.Invoke(ctx, MeetingService_ExportMeetingTranscript_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *meetingServiceClient) GetMeetingChatPolicy(ctx context.Context, in *GetMeetingChatPolicyRequest, opts ...grpc.CallOption) (*MeetingChatPolicy, error) {
+	out := new(MeetingChatPolicy)
+	err := c.cc.Invoke(ctx, MeetingService_GetMeetingChatPolicy_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *meetingServiceClient) UpdateMeetingChatPolicy(ctx context.Context, in *UpdateMeetingChatPolicyRequest, opts ...grpc.CallOption) (*MeetingChatPolicy, error) {
+	out := new(MeetingChatPolicy)
+	err := c.cc.Invoke(ctx, MeetingService_UpdateMeetingChatPolicy_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *meetingServiceClient) GetMeetingUsage(ctx context.Context, in *GetMeetingUsageRequest, opts ...grpc.CallOption) (*MeetingUsage, error) {
+	out := new(MeetingUsage)
+	err := c.cc.Invoke(ctx, MeetingService_GetMeetingUsage_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *meetingServiceClient) SatisfactionMeeting(ctx context.Context, in *SatisfactionMeetingRequest, opts ...grpc.CallOption) (*SatisfactionMeetingResponse, error) {
 	out := new(SatisfactionMeetingResponse)
 	err := c.cc.Invoke(ctx, MeetingService_SatisfactionMeeting_FullMethodName, in, out, opts...)
@@ -117,10 +337,50 @@ type MeetingServiceServer interface {
 	CreateMeetingNA(context.Context, *CreateMeetingRequest) (*CreateMeetingResponse, error)
 	// GetMeetingView retrieves public-facing information about a meeting.
 	GetMeetingView(context.Context, *GetMeetingRequest) (*MeetingView, error)
+	// JoinMeeting issues the short-lived credential of the meeting participant, sent in the x-webitel-guest
+	// header of the participant actions. The valid credential in the header is renewed for the same participant.
+	JoinMeeting(context.Context, *JoinMeetingRequest) (*GuestCredential, error)
+	// RevokeMeetingParticipant stops accepting the credentials of the meeting participant.
+	RevokeMeetingParticipant(context.Context, *RevokeMeetingParticipantRequest) (*RevokeMeetingParticipantResponse, error)
 	// GetMeeting retrieves the full meeting data object.
 	GetMeeting(context.Context, *GetMeetingRequest) (*Meeting, error)
+	// GetMeetingDetails retrieves the meeting with the engine details of its calls.
+	// Recordings are returned only with the playback_record_file permission.
+	GetMeetingDetails(context.Context, *GetMeetingRequest) (*MeetingDetails, error)
 	// DeleteMeeting removes an existing meeting by its unique identifier.
 	DeleteMeeting(context.Context, *DeleteMeetingRequest) (*DeleteMeetingResponse, error)
+	// StartMeetingCall originates the call to the user, queue or number from the meeting variables.
+	StartMeetingCall(context.Context, *StartMeetingCallRequest) (*StartMeetingCallResponse, error)
+	// EndMeeting hangs up the active meeting calls, closes the meeting chat and ends the meeting.
+	EndMeeting(context.Context, *EndMeetingRequest) (*EndMeetingResponse, error)
+	// HoldMeetingCall puts the current meeting call on hold.
+	HoldMeetingCall(context.Context, *MeetingCallRequest) (*MeetingCallResponse, error)
+	// UnHoldMeetingCall resumes the current meeting call.
+	UnHoldMeetingCall(context.Context, *MeetingCallRequest) (*MeetingCallResponse, error)
+	// BlindTransferMeetingCall transfers the current meeting call to the destination.
+	BlindTransferMeetingCall(context.Context, *BlindTransferMeetingCallRequest) (*MeetingCallResponse, error)
+	// DtmfMeetingCall sends DTMF digits to the current meeting call.
+	DtmfMeetingCall(context.Context, *DtmfMeetingCallRequest) (*MeetingCallResponse, error)
+	// LinkMeetingContact links the contact to the meeting conversation, or creates one from the conversation
+	// and the meeting variables (name, phone, email) when no contact id is given.
+	LinkMeetingContact(context.Context, *LinkMeetingContactRequest) (*LinkMeetingContactResponse, error)
+	// LinkMeetingConversationNA is an internal method which records the conversation the chat widget started
+	// for a meeting created without the chat options, so the conversation is closed with the meeting.
+	LinkMeetingConversationNA(context.Context, *LinkMeetingConversationRequest) (*LinkMeetingConversationResponse, error)
+	// SendMeetingMessage sends the customer text message to the meeting chat.
+	SendMeetingMessage(context.Context, *SendMeetingMessageRequest) (*MeetingMessage, error)
+	// SearchMeetingMessages lists the meeting chat messages, newest first.
+	SearchMeetingMessages(context.Context, *SearchMeetingMessagesRequest) (*ListMeetingMessage, error)
+	// GetMeetingTranscript lists the meeting chat messages for the agents, newest first.
+	GetMeetingTranscript(context.Context, *GetMeetingTranscriptRequest) (*MeetingTranscript, error)
+	// ExportMeetingTranscript renders the meeting with its chat messages as a text or JSON file.
+	ExportMeetingTranscript(context.Context, *ExportMeetingTranscriptRequest) (*MeetingTranscriptFile, error)
+	// GetMeetingChatPolicy returns what happens to the meeting chat of the domain once the agent call ends.
+	GetMeetingChatPolicy(context.Context, *GetMeetingChatPolicyRequest) (*MeetingChatPolicy, error)
+	// UpdateMeetingChatPolicy sets what happens to the meeting chat of the domain once the agent call ends.
+	UpdateMeetingChatPolicy(context.Context, *UpdateMeetingChatPolicyRequest) (*MeetingChatPolicy, error)
+	// GetMeetingUsage returns the meetings of the caller domain against the quota.
+	GetMeetingUsage(context.Context, *GetMeetingUsageRequest) (*MeetingUsage, error)
 	// SatisfactionMeeting submits feedback or a satisfaction rating for a completed meeting.
 	SatisfactionMeeting(context.Context, *SatisfactionMeetingRequest) (*SatisfactionMeetingResponse, error)
 	mustEmbedUnimplementedMeetingServiceServer()
@@ -139,12 +399,66 @@ func (UnimplementedMeetingServiceServer) CreateMeetingNA(context.Context, *Creat
 func (UnimplementedMeetingServiceServer) GetMeetingView(context.Context, *GetMeetingRequest) (*MeetingView, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetMeetingView not implemented")
 }
+func (UnimplementedMeetingServiceServer) JoinMeeting(context.Context, *JoinMeetingRequest) (*GuestCredential, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method JoinMeeting not implemented")
+}
+func (UnimplementedMeetingServiceServer) RevokeMeetingParticipant(context.Context, *RevokeMeetingParticipantRequest) (*RevokeMeetingParticipantResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeMeetingParticipant not implemented")
+}
 func (UnimplementedMeetingServiceServer) GetMeeting(context.Context, *GetMeetingRequest) (*Meeting, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetMeeting not implemented")
 }
+func (UnimplementedMeetingServiceServer) GetMeetingDetails(context.Context, *GetMeetingRequest) (*MeetingDetails, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMeetingDetails not implemented")
+}
 func (UnimplementedMeetingServiceServer) DeleteMeeting(context.Context, *DeleteMeetingRequest) (*DeleteMeetingResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteMeeting not implemented")
 }
+func (UnimplementedMeetingServiceServer) StartMeetingCall(context.Context, *StartMeetingCallRequest) (*StartMeetingCallResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartMeetingCall not implemented")
+}
+func (UnimplementedMeetingServiceServer) EndMeeting(context.Context, *EndMeetingRequest) (*EndMeetingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EndMeeting not implemented")
+}
+func (UnimplementedMeetingServiceServer) HoldMeetingCall(context.Context, *MeetingCallRequest) (*MeetingCallResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HoldMeetingCall not implemented")
+}
+func (UnimplementedMeetingServiceServer) UnHoldMeetingCall(context.Context, *MeetingCallRequest) (*MeetingCallResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnHoldMeetingCall not implemented")
+}
+func (UnimplementedMeetingServiceServer) BlindTransferMeetingCall(context.Context, *BlindTransferMeetingCallRequest) (*MeetingCallResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BlindTransferMeetingCall not implemented")
+}
+func (UnimplementedMeetingServiceServer) DtmfMeetingCall(context.Context, *DtmfMeetingCallRequest) (*MeetingCallResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DtmfMeetingCall not implemented")
+}
+func (UnimplementedMeetingServiceServer) LinkMeetingContact(context.Context, *LinkMeetingContactRequest) (*LinkMeetingContactResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LinkMeetingContact not implemented")
+}
+func (UnimplementedMeetingServiceServer) LinkMeetingConversationNA(context.Context, *LinkMeetingConversationRequest) (*LinkMeetingConversationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LinkMeetingConversationNA not implemented")
+}
+func (UnimplementedMeetingServiceServer) SendMeetingMessage(context.Context, *SendMeetingMessageRequest) (*MeetingMessage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendMeetingMessage not implemented")
+}
+func (UnimplementedMeetingServiceServer) SearchMeetingMessages(context.Context, *SearchMeetingMessagesRequest) (*ListMeetingMessage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchMeetingMessages not implemented")
+}
+func (UnimplementedMeetingServiceServer) GetMeetingTranscript(context.Context, *GetMeetingTranscriptRequest) (*MeetingTranscript, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMeetingTranscript not implemented")
+}
+func (UnimplementedMeetingServiceServer) ExportMeetingTranscript(context.Context, *ExportMeetingTranscriptRequest) (*MeetingTranscriptFile, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportMeetingTranscript not implemented")
+}
+func (UnimplementedMeetingServiceServer) GetMeetingChatPolicy(context.Context, *GetMeetingChatPolicyRequest) (*MeetingChatPolicy, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMeetingChatPolicy not implemented")
+}
+func (UnimplementedMeetingServiceServer) UpdateMeetingChatPolicy(context.Context, *UpdateMeetingChatPolicyRequest) (*MeetingChatPolicy, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateMeetingChatPolicy not implemented")
+}
+func (UnimplementedMeetingServiceServer) GetMeetingUsage(context.Context, *GetMeetingUsageRequest) (*MeetingUsage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMeetingUsage not implemented")
+}
 func (UnimplementedMeetingServiceServer) SatisfactionMeeting(context.Context, *SatisfactionMeetingRequest) (*SatisfactionMeetingResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SatisfactionMeeting not implemented")
 }
@@ -215,6 +529,42 @@ func _MeetingService_GetMeetingView_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MeetingService_JoinMeeting_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JoinMeetingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MeetingServiceServer).JoinMeeting(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MeetingService_JoinMeeting_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MeetingServiceServer).JoinMeeting(ctx, req.(*JoinMeetingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MeetingService_RevokeMeetingParticipant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeMeetingParticipantRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MeetingServiceServer).RevokeMeetingParticipant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MeetingService_RevokeMeetingParticipant_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MeetingServiceServer).RevokeMeetingParticipant(ctx, req.(*RevokeMeetingParticipantRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _MeetingService_GetMeeting_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetMeetingRequest)
 	if err := dec(in); err != nil {
@@ -233,6 +583,24 @@ func _MeetingService_GetMeeting_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MeetingService_GetMeetingDetails_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMeetingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MeetingServiceServer).GetMeetingDetails(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MeetingService_GetMeetingDetails_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MeetingServiceServer).GetMeetingDetails(ctx, req.(*GetMeetingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _MeetingService_DeleteMeeting_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DeleteMeetingRequest)
 	if err := dec(in); err != nil {
@@ -251,54 +619,683 @@ func _MeetingService_DeleteMeeting_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
-func _MeetingService_SatisfactionMeeting_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SatisfactionMeetingRequest)
+func _MeetingService_StartMeetingCall_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartMeetingCallRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MeetingServiceServer).SatisfactionMeeting(ctx, in)
+		return srv.(MeetingServiceServer).StartMeetingCall(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: MeetingService_SatisfactionMeeting_FullMethodName,
+		FullMethod: MeetingService_StartMeetingCall_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MeetingServiceServer).SatisfactionMeeting(ctx, req.(*SatisfactionMeetingRequest))
+		return srv.(MeetingServiceServer).StartMeetingCall(ctx, req.(*StartMeetingCallRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-// MeetingService_ServiceDesc is the grpc.ServiceDesc for MeetingService service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var MeetingService_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "web_meeting_backend.MeetingService",
-	HandlerType: (*MeetingServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "CreateMeeting",
-			Handler:    _MeetingService_CreateMeeting_Handler,
-		},
-		{
-			MethodName: "CreateMeetingNA",
-			Handler:    _MeetingService_CreateMeetingNA_Handler,
-		},
-		{
-			MethodName: "GetMeetingView",
-			Handler:    _MeetingService_GetMeetingView_Handler,
-		},
-		{
-			MethodName: "GetMeeting",
-			Handler:    _MeetingService_GetMeeting_Handler,
-		},
-		{
-			MethodName: "DeleteMeeting",
-			Handler:    _MeetingService_DeleteMeeting_Handler,
-		},
-		{
-			MethodName: "SatisfactionMeeting",
-			Handler:    _MeetingService_SatisfactionMeeting_Handler,
+func _MeetingService_EndMeeting_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EndMeetingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MeetingServiceServer).EndMeeting(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MeetingService_EndMeeting_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MeetingServiceServer).EndMeeting(ctx, req.(*EndMeetingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MeetingService_HoldMeetingCall_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MeetingCallRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MeetingServiceServer).HoldMeetingCall(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MeetingService_HoldMeetingCall_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MeetingServiceServer).HoldMeetingCall(ctx, req.(*MeetingCallRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MeetingService_UnHoldMeetingCall_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MeetingCallRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MeetingServiceServer).UnHoldMeetingCall(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MeetingService_UnHoldMeetingCall_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MeetingServiceServer).UnHoldMeetingCall(ctx, req.(*MeetingCallRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MeetingService_BlindTransferMeetingCall_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BlindTransferMeetingCallRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MeetingServiceServer).BlindTransferMeetingCall(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MeetingService_BlindTransferMeetingCall_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MeetingServiceServer).BlindTransferMeetingCall(ctx, req.(*BlindTransferMeetingCallRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MeetingService_DtmfMeetingCall_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DtmfMeetingCallRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MeetingServiceServer).DtmfMeetingCall(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MeetingService_DtmfMeetingCall_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MeetingServiceServer).DtmfMeetingCall(ctx, req.(*DtmfMeetingCallRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MeetingService_LinkMeetingContact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LinkMeetingContactRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MeetingServiceServer).LinkMeetingContact(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MeetingService_LinkMeetingContact_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MeetingServiceServer).LinkMeetingContact(ctx, req.(*LinkMeetingContactRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MeetingService_LinkMeetingConversationNA_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LinkMeetingConversationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MeetingServiceServer).LinkMeetingConversationNA(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MeetingService_LinkMeetingConversationNA_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MeetingServiceServer).LinkMeetingConversationNA(ctx, req.(*LinkMeetingConversationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MeetingService_SendMeetingMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendMeetingMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MeetingServiceServer).SendMeetingMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MeetingService_SendMeetingMessage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MeetingServiceServer).SendMeetingMessage(ctx, req.(*SendMeetingMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MeetingService_SearchMeetingMessages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchMeetingMessagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MeetingServiceServer).SearchMeetingMessages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MeetingService_SearchMeetingMessages_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MeetingServiceServer).SearchMeetingMessages(ctx, req.(*SearchMeetingMessagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MeetingService_GetMeetingTranscript_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMeetingTranscriptRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MeetingServiceServer).GetMeetingTranscript(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MeetingService_GetMeetingTranscript_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MeetingServiceServer).GetMeetingTranscript(ctx, req.(*GetMeetingTranscriptRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MeetingService_ExportMeetingTranscript_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportMeetingTranscriptRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MeetingServiceServer).ExportMeetingTranscript(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MeetingService_ExportMeetingTranscript_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MeetingServiceServer).ExportMeetingTranscript(ctx, req.(*ExportMeetingTranscriptRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MeetingService_GetMeetingChatPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMeetingChatPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MeetingServiceServer).GetMeetingChatPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MeetingService_GetMeetingChatPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MeetingServiceServer).GetMeetingChatPolicy(ctx, req.(*GetMeetingChatPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MeetingService_UpdateMeetingChatPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateMeetingChatPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MeetingServiceServer).UpdateMeetingChatPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MeetingService_UpdateMeetingChatPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MeetingServiceServer).UpdateMeetingChatPolicy(ctx, req.(*UpdateMeetingChatPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MeetingService_GetMeetingUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMeetingUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MeetingServiceServer).GetMeetingUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MeetingService_GetMeetingUsage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MeetingServiceServer).GetMeetingUsage(ctx, req.(*GetMeetingUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MeetingService_SatisfactionMeeting_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SatisfactionMeetingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MeetingServiceServer).SatisfactionMeeting(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MeetingService_SatisfactionMeeting_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MeetingServiceServer).SatisfactionMeeting(ctx, req.(*SatisfactionMeetingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// MeetingService_ServiceDesc is the grpc.ServiceDesc for MeetingService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var MeetingService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "web_meeting_backend.MeetingService",
+	HandlerType: (*MeetingServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateMeeting",
+			Handler:    _MeetingService_CreateMeeting_Handler,
+		},
+		{
+			MethodName: "CreateMeetingNA",
+			Handler:    _MeetingService_CreateMeetingNA_Handler,
+		},
+		{
+			MethodName: "GetMeetingView",
+			Handler:    _MeetingService_GetMeetingView_Handler,
+		},
+		{
+			MethodName: "JoinMeeting",
+			Handler:    _MeetingService_JoinMeeting_Handler,
+		},
+		{
+			MethodName: "RevokeMeetingParticipant",
+			Handler:    _MeetingService_RevokeMeetingParticipant_Handler,
+		},
+		{
+			MethodName: "GetMeeting",
+			Handler:    _MeetingService_GetMeeting_Handler,
+		},
+		{
+			MethodName: "GetMeetingDetails",
+			Handler:    _MeetingService_GetMeetingDetails_Handler,
+		},
+		{
+			MethodName: "DeleteMeeting",
+			Handler:    _MeetingService_DeleteMeeting_Handler,
+		},
+		{
+			MethodName: "StartMeetingCall",
+			Handler:    _MeetingService_StartMeetingCall_Handler,
+		},
+		{
+			MethodName: "EndMeeting",
+			Handler:    _MeetingService_EndMeeting_Handler,
+		},
+		{
+			MethodName: "HoldMeetingCall",
+			Handler:    _MeetingService_HoldMeetingCall_Handler,
+		},
+		{
+			MethodName: "UnHoldMeetingCall",
+			Handler:    _MeetingService_UnHoldMeetingCall_Handler,
+		},
+		{
+			MethodName: "BlindTransferMeetingCall",
+			Handler:    _MeetingService_BlindTransferMeetingCall_Handler,
+		},
+		{
+			MethodName: "DtmfMeetingCall",
+			Handler:    _MeetingService_DtmfMeetingCall_Handler,
+		},
+		{
+			MethodName: "LinkMeetingContact",
+			Handler:    _MeetingService_LinkMeetingContact_Handler,
+		},
+		{
+			MethodName: "LinkMeetingConversationNA",
+			Handler:    _MeetingService_LinkMeetingConversationNA_Handler,
+		},
+		{
+			MethodName: "SendMeetingMessage",
+			Handler:    _MeetingService_SendMeetingMessage_Handler,
+		},
+		{
+			MethodName: "SearchMeetingMessages",
+			Handler:    _MeetingService_SearchMeetingMessages_Handler,
+		},
+		{
+			MethodName: "GetMeetingTranscript",
+			Handler:    _MeetingService_GetMeetingTranscript_Handler,
+		},
+		{
+			MethodName: "ExportMeetingTranscript",
+			Handler:    _MeetingService_ExportMeetingTranscript_Handler,
+		},
+		{
+			MethodName: "GetMeetingChatPolicy",
+			Handler:    _MeetingService_GetMeetingChatPolicy_Handler,
+		},
+		{
+			MethodName: "UpdateMeetingChatPolicy",
+			Handler:    _MeetingService_UpdateMeetingChatPolicy_Handler,
+		},
+		{
+			MethodName: "GetMeetingUsage",
+			Handler:    _MeetingService_GetMeetingUsage_Handler,
+		},
+		{
+			MethodName: "SatisfactionMeeting",
+			Handler:    _MeetingService_SatisfactionMeeting_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "web_meeting.proto",
+}
+
+const (
+	WebhookService_CreateWebhook_FullMethodName         = "/web_meeting_backend.WebhookService/CreateWebhook"
+	WebhookService_SearchWebhook_FullMethodName         = "/web_meeting_backend.WebhookService/SearchWebhook"
+	WebhookService_ReadWebhook_FullMethodName           = "/web_meeting_backend.WebhookService/ReadWebhook"
+	WebhookService_UpdateWebhook_FullMethodName         = "/web_meeting_backend.WebhookService/UpdateWebhook"
+	WebhookService_DeleteWebhook_FullMethodName         = "/web_meeting_backend.WebhookService/DeleteWebhook"
+	WebhookService_SearchWebhookDelivery_FullMethodName = "/web_meeting_backend.WebhookService/SearchWebhookDelivery"
+)
+
+// WebhookServiceClient is the client API for WebhookService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type WebhookServiceClient interface {
+	// CreateWebhook subscribes a URL to meeting lifecycle events of the domain.
+	CreateWebhook(ctx context.Context, in *CreateWebhookRequest, opts ...grpc.CallOption) (*Webhook, error)
+	// SearchWebhook lists webhook subscriptions of the domain.
+	SearchWebhook(ctx context.Context, in *SearchWebhookRequest, opts ...grpc.CallOption) (*ListWebhook, error)
+	// ReadWebhook retrieves a webhook subscription by its identifier.
+	ReadWebhook(ctx context.Context, in *ReadWebhookRequest, opts ...grpc.CallOption) (*Webhook, error)
+	// UpdateWebhook changes a webhook subscription; enabling it resets the failure counter.
+	UpdateWebhook(ctx context.Context, in *UpdateWebhookRequest, opts ...grpc.CallOption) (*Webhook, error)
+	// DeleteWebhook removes a webhook subscription with its delivery logs.
+	DeleteWebhook(ctx context.Context, in *DeleteWebhookRequest, opts ...grpc.CallOption) (*DeleteWebhookResponse, error)
+	// SearchWebhookDelivery lists delivery attempts of a webhook, newest first.
+	SearchWebhookDelivery(ctx context.Context, in *SearchWebhookDeliveryRequest, opts ...grpc.CallOption) (*ListWebhookDelivery, error)
+}
+
+type webhookServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewWebhookServiceClient(cc grpc.ClientConnInterface) WebhookServiceClient {
+	return &webhookServiceClient{cc}
+}
+
+func (c *webhookServiceClient) CreateWebhook(ctx context.Context, in *CreateWebhookRequest, opts ...grpc.CallOption) (*Webhook, error) {
+	out := new(Webhook)
+	err := c.cc.Invoke(ctx, WebhookService_CreateWebhook_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webhookServiceClient) SearchWebhook(ctx context.Context, in *SearchWebhookRequest, opts ...grpc.CallOption) (*ListWebhook, error) {
+	out := new(ListWebhook)
+	err := c.cc.Invoke(ctx, WebhookService_SearchWebhook_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webhookServiceClient) ReadWebhook(ctx context.Context, in *ReadWebhookRequest, opts ...grpc.CallOption) (*Webhook, error) {
+	out := new(Webhook)
+	err := c.cc.Invoke(ctx, WebhookService_ReadWebhook_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webhookServiceClient) UpdateWebhook(ctx context.Context, in *UpdateWebhookRequest, opts ...grpc.CallOption) (*Webhook, error) {
+	out := new(Webhook)
+	err := c.cc.Invoke(ctx, WebhookService_UpdateWebhook_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webhookServiceClient) DeleteWebhook(ctx context.Context, in *DeleteWebhookRequest, opts ...grpc.CallOption) (*DeleteWebhookResponse, error) {
+	out := new(DeleteWebhookResponse)
+	err := c.cc.Invoke(ctx, WebhookService_DeleteWebhook_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webhookServiceClient) SearchWebhookDelivery(ctx context.Context, in *SearchWebhookDeliveryRequest, opts ...grpc.CallOption) (*ListWebhookDelivery, error) {
+	out := new(ListWebhookDelivery)
+	err := c.cc.Invoke(ctx, WebhookService_SearchWebhookDelivery_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// WebhookServiceServer is the server API for WebhookService service.
+// All implementations must embed UnimplementedWebhookServiceServer
+// for forward compatibility
+type WebhookServiceServer interface {
+	// CreateWebhook subscribes a URL to meeting lifecycle events of the domain.
+	CreateWebhook(context.Context, *CreateWebhookRequest) (*Webhook, error)
+	// SearchWebhook lists webhook subscriptions of the domain.
+	SearchWebhook(context.Context, *SearchWebhookRequest) (*ListWebhook, error)
+	// ReadWebhook retrieves a webhook subscription by its identifier.
+	ReadWebhook(context.Context, *ReadWebhookRequest) (*Webhook, error)
+	// UpdateWebhook changes a webhook subscription; enabling it resets the failure counter.
+	UpdateWebhook(context.Context, *UpdateWebhookRequest) (*Webhook, error)
+	// DeleteWebhook removes a webhook subscription with its delivery logs.
+	DeleteWebhook(context.Context, *DeleteWebhookRequest) (*DeleteWebhookResponse, error)
+	// SearchWebhookDelivery lists delivery attempts of a webhook, newest first.
+	SearchWebhookDelivery(context.Context, *SearchWebhookDeliveryRequest) (*ListWebhookDelivery, error)
+	mustEmbedUnimplementedWebhookServiceServer()
+}
+
+// UnimplementedWebhookServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedWebhookServiceServer struct {
+}
+
+func (UnimplementedWebhookServiceServer) CreateWebhook(context.Context, *CreateWebhookRequest) (*Webhook, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateWebhook not implemented")
+}
+func (UnimplementedWebhookServiceServer) SearchWebhook(context.Context, *SearchWebhookRequest) (*ListWebhook, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchWebhook not implemented")
+}
+func (UnimplementedWebhookServiceServer) ReadWebhook(context.Context, *ReadWebhookRequest) (*Webhook, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReadWebhook not implemented")
+}
+func (UnimplementedWebhookServiceServer) UpdateWebhook(context.Context, *UpdateWebhookRequest) (*Webhook, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateWebhook not implemented")
+}
+func (UnimplementedWebhookServiceServer) DeleteWebhook(context.Context, *DeleteWebhookRequest) (*DeleteWebhookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteWebhook not implemented")
+}
+func (UnimplementedWebhookServiceServer) SearchWebhookDelivery(context.Context, *SearchWebhookDeliveryRequest) (*ListWebhookDelivery, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchWebhookDelivery not implemented")
+}
+func (UnimplementedWebhookServiceServer) mustEmbedUnimplementedWebhookServiceServer() {}
+
+// UnsafeWebhookServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to WebhookServiceServer will
+// result in compilation errors.
+type UnsafeWebhookServiceServer interface {
+	mustEmbedUnimplementedWebhookServiceServer()
+}
+
+func RegisterWebhookServiceServer(s grpc.ServiceRegistrar, srv WebhookServiceServer) {
+	s.RegisterService(&WebhookService_ServiceDesc, srv)
+}
+
+func _WebhookService_CreateWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhookServiceServer).CreateWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WebhookService_CreateWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhookServiceServer).CreateWebhook(ctx, req.(*CreateWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WebhookService_SearchWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhookServiceServer).SearchWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WebhookService_SearchWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhookServiceServer).SearchWebhook(ctx, req.(*SearchWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WebhookService_ReadWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhookServiceServer).ReadWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WebhookService_ReadWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhookServiceServer).ReadWebhook(ctx, req.(*ReadWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WebhookService_UpdateWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhookServiceServer).UpdateWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WebhookService_UpdateWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhookServiceServer).UpdateWebhook(ctx, req.(*UpdateWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WebhookService_DeleteWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhookServiceServer).DeleteWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WebhookService_DeleteWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhookServiceServer).DeleteWebhook(ctx, req.(*DeleteWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WebhookService_SearchWebhookDelivery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchWebhookDeliveryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhookServiceServer).SearchWebhookDelivery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WebhookService_SearchWebhookDelivery_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhookServiceServer).SearchWebhookDelivery(ctx, req.(*SearchWebhookDeliveryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// WebhookService_ServiceDesc is the grpc.ServiceDesc for WebhookService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var WebhookService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "web_meeting_backend.WebhookService",
+	HandlerType: (*WebhookServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateWebhook",
+			Handler:    _WebhookService_CreateWebhook_Handler,
+		},
+		{
+			MethodName: "SearchWebhook",
+			Handler:    _WebhookService_SearchWebhook_Handler,
+		},
+		{
+			MethodName: "ReadWebhook",
+			Handler:    _WebhookService_ReadWebhook_Handler,
+		},
+		{
+			MethodName: "UpdateWebhook",
+			Handler:    _WebhookService_UpdateWebhook_Handler,
+		},
+		{
+			MethodName: "DeleteWebhook",
+			Handler:    _WebhookService_DeleteWebhook_Handler,
+		},
+		{
+			MethodName: "SearchWebhookDelivery",
+			Handler:    _WebhookService_SearchWebhookDelivery_Handler,
 		},
 	},
 	Streams:  []grpc.StreamDesc{},
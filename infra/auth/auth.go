@@ -20,29 +20,48 @@ type Manager interface {
 	Stop()
 	GetSession(ctx context.Context, token string) (*Session, error)
 	ProductLimit(ctx context.Context, token, productName string) (int, error)
+	// DomainLicense reports whether the domain holds the valid product license, e.g. for the internal
+	// callers without the user session.
+	DomainLicense(ctx context.Context, domainId int64, productName string) (bool, error)
+	// Evict drops the cached session of the token, e.g. after the logout.
+	Evict(token string)
+	// EvictUser drops the cached sessions of the user, e.g. when they are revoked.
+	EvictUser(userId int64)
 }
 
 type authManager struct {
 	session    *expirable.LRU[string, *Session]
+	denied     *expirable.LRU[string, struct{}]
 	startOnce  sync.Once
 	consulAddr string
 	auth       *grpc_client.Client[api.AuthClient]
 	customer   *grpc_client.Client[api.CustomersClient]
+	fetch      func(ctx context.Context, token string) (*Session, error)
 
 	log *wlog.Logger
 }
 
-func NewAuthManager(cacheSize int, cacheTime int64, consulAddr string, log *wlog.Logger) Manager {
-	if cacheTime < 1 {
+// NewAuthManager caches the sessions for the cache time and the invalid tokens for the denied time,
+// zero denied time disables the negative cache.
+func NewAuthManager(cacheSize int, cacheTime, deniedTime time.Duration, consulAddr string, log *wlog.Logger) Manager {
+	if cacheTime < time.Second {
 		// 0 disabled cache
-		cacheTime = 1
+		cacheTime = time.Second
 	}
 
-	return &authManager{
+	am := &authManager{
 		consulAddr: consulAddr,
-		session:    expirable.NewLRU[string, *Session](cacheSize, nil, time.Second*time.Duration(cacheTime)),
+		session:    expirable.NewLRU[string, *Session](cacheSize, nil, cacheTime),
 		log:        log.With(wlog.Namespace("context")).With(wlog.String("scope", "auth_manager")),
 	}
+
+	if deniedTime > 0 {
+		am.denied = expirable.NewLRU[string, struct{}](cacheSize, nil, deniedTime)
+	}
+
+	am.fetch = am.fetchSession
+
+	return am
 }
 
 func (am *authManager) Start() error {
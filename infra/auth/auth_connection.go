@@ -61,7 +61,35 @@ func (am *authManager) ProductLimit(ctx context.Context, token, productName stri
 	return int(limitMax), nil
 }
 
-func (am *authManager) GetSession(c context.Context, token string) (*Session, error) {
+func (am *authManager) DomainLicense(ctx context.Context, domainId int64, productName string) (bool, error) {
+	tenant, err := am.customer.API.GetCustomer(ctx, &api.GetCustomerRequest{
+		Valid:  true,
+		Domain: &api.ObjectId{Id: domainId},
+	})
+	if err != nil {
+		return false, err
+	}
+
+	for _, grant := range tenant.GetCustomer().GetLicense() {
+		if grant.GetProduct() != productName {
+			continue
+		}
+
+		if errs := grant.GetStatus().GetErrors(); len(errs) != 0 {
+			// Same as ProductLimit, single 'product exhausted' error keeps the license valid
+			if len(errs) != 1 || errs[0] != "product exhausted" {
+				continue // Currently invalid
+			}
+		}
+
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// fetchSession reads the session of the token from the auth service.
+func (am *authManager) fetchSession(c context.Context, token string) (*Session, error) {
 	ctx := grpc_client.WithToken(c, token)
 
 	resp, err := am.auth.API.UserInfo(ctx, &api.UserinfoRequest{})
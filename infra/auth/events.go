@@ -0,0 +1,86 @@
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// SessionEvent is the auth event which ends the session of the token, or all the sessions of the user,
+// e.g. the logout or the revoked session.
+type SessionEvent struct {
+	Token  string
+	UserId int64
+}
+
+// SessionEventFields names the JSON fields of the session event, the payload is defined by the auth service
+// which publishes the events, so the names are configured to match it.
+type SessionEventFields struct {
+	Token  string
+	UserId string
+}
+
+// DefaultSessionEventFields reads the `{"token": "...", "user_id": 10}` events.
+var DefaultSessionEventFields = SessionEventFields{
+	Token:  "token",
+	UserId: "user_id",
+}
+
+// ParseSessionEvent parses the JSON session event with the fields, the user id is a number or a numeric string.
+func ParseSessionEvent(data []byte, fields SessionEventFields) (*SessionEvent, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("invalid session event: %w", err)
+	}
+
+	var e SessionEvent
+	if v, ok := raw[fields.Token]; ok && fields.Token != "" {
+		if err := json.Unmarshal(v, &e.Token); err != nil {
+			return nil, fmt.Errorf("invalid session event %s: %w", fields.Token, err)
+		}
+	}
+
+	if v, ok := raw[fields.UserId]; ok && fields.UserId != "" {
+		id, err := parseUserId(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid session event %s: %w", fields.UserId, err)
+		}
+		e.UserId = id
+	}
+
+	if e.Token == "" && e.UserId == 0 {
+		return nil, errors.New("invalid session event: token or user id is required")
+	}
+
+	return &e, nil
+}
+
+func parseUserId(v json.RawMessage) (int64, error) {
+	var id int64
+	if err := json.Unmarshal(v, &id); err == nil {
+		return id, nil
+	}
+
+	var s string
+	if err := json.Unmarshal(v, &s); err != nil {
+		return 0, err
+	}
+
+	if s == "" {
+		return 0, nil
+	}
+
+	return strconv.ParseInt(s, 10, 64)
+}
+
+// EvictSession drops the cached sessions ended by the event.
+func EvictSession(am Manager, e *SessionEvent) {
+	if e.Token != "" {
+		am.Evict(e.Token)
+	}
+
+	if e.UserId != 0 {
+		am.EvictUser(e.UserId)
+	}
+}
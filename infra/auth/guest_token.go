@@ -0,0 +1,93 @@
+package auth
+
+import (
+	"crypto/hkdf"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// GuestTokenHeaderName carries the credential of the meeting participant.
+const GuestTokenHeaderName = "x-webitel-guest"
+
+// DefaultGuestTTL is the lifetime of the guest credential; the participant joins again to renew it.
+const DefaultGuestTTL = 30 * time.Minute
+
+var ErrInvalidGuestToken = errors.New("invalid guest token")
+
+// GuestSigner issues the short-lived credentials of the meeting participants.
+// The token is "<participant id>.<unix expiry>.<base64url HMAC-SHA256>", the signature also covers
+// the meeting id, so the token is valid for its meeting only.
+type GuestSigner struct {
+	secret []byte
+	ttl    time.Duration
+}
+
+// guestKeyLabel is the HKDF info of the guest token key, so the service secret,
+// the data encryption key, is never used for the signatures as is.
+const guestKeyLabel = "guest-token"
+
+// NewGuestSigner returns the signer with the key derived from the secret, a random one when empty.
+func NewGuestSigner(secret string, ttl time.Duration) *GuestSigner {
+	var key []byte
+	if secret != "" {
+		// HKDF-SHA256 fails only for the key longer than 255 hashes
+		key, _ = hkdf.Key(sha256.New, []byte(secret), nil, guestKeyLabel, sha256.Size)
+	} else {
+		key = make([]byte, sha256.Size)
+		_, _ = rand.Read(key)
+	}
+
+	if ttl <= 0 {
+		ttl = DefaultGuestTTL
+	}
+
+	return &GuestSigner{
+		secret: key,
+		ttl:    ttl,
+	}
+}
+
+// Issue returns the token of the meeting participant and its expiry in unix seconds.
+func (s *GuestSigner) Issue(meetingId, participantId string, now time.Time) (string, int64) {
+	exp := now.Add(s.ttl).Unix()
+	payload := participantId + "." + strconv.FormatInt(exp, 10)
+
+	return payload + "." + s.signature(meetingId, participantId, exp), exp
+}
+
+// Verify checks the token of the meeting at the time and returns the participant id.
+func (s *GuestSigner) Verify(token, meetingId string, now time.Time) (string, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || parts[0] == "" {
+		return "", ErrInvalidGuestToken
+	}
+
+	exp, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return "", ErrInvalidGuestToken
+	}
+
+	if !hmac.Equal([]byte(s.signature(meetingId, parts[0], exp)), []byte(parts[2])) {
+		return "", ErrInvalidGuestToken
+	}
+
+	if now.Unix() >= exp {
+		return "", fmt.Errorf("%w: expired", ErrInvalidGuestToken)
+	}
+
+	return parts[0], nil
+}
+
+func (s *GuestSigner) signature(meetingId, participantId string, exp int64) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte("guest:" + meetingId + ":" + participantId + ":" + strconv.FormatInt(exp, 10)))
+
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
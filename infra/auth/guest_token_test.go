@@ -0,0 +1,39 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGuestSigner(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	signer := NewGuestSigner("secret", time.Minute)
+
+	token, exp := signer.Issue("meeting", "guest", now)
+	assert.Equal(t, now.Add(time.Minute).Unix(), exp)
+
+	participant, err := signer.Verify(token, "meeting", now.Add(30*time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, "guest", participant)
+
+	_, err = signer.Verify(token, "other", now)
+	assert.ErrorIs(t, err, ErrInvalidGuestToken, "token of another meeting")
+
+	_, err = signer.Verify(token, "meeting", now.Add(time.Minute))
+	assert.ErrorIs(t, err, ErrInvalidGuestToken, "expired token")
+
+	_, err = NewGuestSigner("other", time.Minute).Verify(token, "meeting", now)
+	assert.ErrorIs(t, err, ErrInvalidGuestToken)
+
+	_, err = signer.Verify("guest.9999999999."+signer.signature("meeting", "admin", 9999999999), "meeting", now)
+	assert.ErrorIs(t, err, ErrInvalidGuestToken)
+
+	// the token is not signed with the service secret itself
+	assert.NotEqual(t, []byte("secret"), signer.secret)
+	assert.Len(t, signer.secret, sha256.Size)
+	assert.Equal(t, signer.secret, NewGuestSigner("secret", time.Minute).secret)
+}
@@ -5,7 +5,7 @@ type SessionPermission struct {
 	Name string `json:"name"`
 	// Abac   bool   `json:"abac"`
 	Obac   bool   `json:"obac"`
-	rbac   bool   `json:"rbac"`
+	rbac   bool   `json:"-"`
 	Access uint32 `json:"access"`
 }
 
@@ -29,6 +29,11 @@ const (
 	PermissionResetActiveAttempts   = "reset_active_attempts"
 )
 
+const (
+	ScopeCalls    = "calls"
+	ScopeContacts = "contacts"
+)
+
 func (p PermissionAccess) Value() uint32 {
 	return [...]uint32{8, 4, 2, 1}[p]
 }
@@ -0,0 +1,45 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServiceSigner(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	signer := NewServiceSigner("secret")
+
+	const method = "/svc/CreateMeetingNA"
+
+	token, err := signer.Sign("flow_manager", method, now)
+	require.NoError(t, err)
+
+	service, err := signer.Verify(token, method, now.Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, "flow_manager", service)
+
+	// the token is bound to the method
+	_, err = signer.Verify(token, "/svc/DeleteMeeting", now)
+	assert.ErrorIs(t, err, ErrInvalidServiceToken)
+
+	_, err = signer.Verify(token, method, now.Add(ServiceTokenTTL+time.Second))
+	assert.ErrorIs(t, err, ErrInvalidServiceToken)
+
+	_, err = NewServiceSigner("other").Verify(token, method, now)
+	assert.ErrorIs(t, err, ErrInvalidServiceToken)
+
+	_, err = signer.Verify("engine:1700000000:"+signer.signature("flow_manager:1700000000:"+method), method, now)
+	assert.ErrorIs(t, err, ErrInvalidServiceToken)
+
+	_, err = NewServiceSigner("").Verify(token, method, now)
+	assert.ErrorIs(t, err, ErrInvalidServiceToken)
+
+	_, err = signer.Sign("a:b", method, now)
+	assert.Error(t, err)
+
+	_, err = signer.Sign("flow_manager", "", now)
+	assert.Error(t, err)
+}
@@ -2,6 +2,7 @@ package auth
 
 import (
 	"context"
+	"errors"
 	"sync/atomic"
 	"time"
 
@@ -21,7 +22,7 @@ type Session struct {
 	DomainName string       `json:"domain_name"`
 	Expire     int64        `json:"expire"`
 	UserID     int64        `json:"user_id"`
-	userIP     atomic.Value `json:"user_ip"`
+	userIP     atomic.Value `json:"-"`
 	RoleIDs    []int        `json:"role_ids"`
 
 	Token            string              `json:"token"`
@@ -32,6 +33,15 @@ type Session struct {
 	validLicense     []string
 }
 
+// NewSession builds the session of the domain user granted the actions, e.g. in the tests of the handlers.
+func NewSession(domainId, userId int64, actions ...string) *Session {
+	return &Session{
+		DomainID: domainId,
+		UserID:   userId,
+		actions:  actions,
+	}
+}
+
 func (s *Session) UseRBAC(acc PermissionAccess, perm SessionPermission) bool {
 	if !perm.rbac {
 		return false
@@ -160,27 +170,55 @@ func (s *Session) HasAction(name string) bool {
 	return false
 }
 
-func (am *authManager) getSession(c context.Context, token string) (Session, error) {
+// GetSession returns the cached session of the token, or reads it from the auth service;
+// the invalid token is denied from the cache as well.
+func (am *authManager) GetSession(c context.Context, token string) (*Session, error) {
 	if v, ok := am.session.Get(token); ok {
-		return *v, nil
+		session := *v
+		return &session, nil
+	}
+
+	if am.denied != nil && am.denied.Contains(token) {
+		return nil, ErrStatusUnauthenticated
 	}
 
 	result, err, shared := sessionGroupRequest.Do(token, func() (any, error) {
 		ctx, cancel := context.WithTimeout(c, tokenRequestTimeout)
 		defer cancel()
 
-		return am.GetSession(ctx, token)
+		return am.fetch(ctx, token)
 	})
 	if err != nil {
-		return Session{}, err
+		if !shared && am.denied != nil && errors.Is(err, ErrStatusUnauthenticated) {
+			am.denied.Add(token, struct{}{})
+		}
+
+		return nil, err
 	}
 
-	session := result.(*Session)
+	cached := result.(*Session)
 
 	if !shared {
-		am.session.Add(token, session)
-		am.log.With(wlog.String("user_name", session.Name)).Debug("store")
+		am.session.Add(token, cached)
+		am.log.With(wlog.String("user_name", cached.Name)).Debug("store")
 	}
 
-	return *session, nil
+	session := *cached
+
+	return &session, nil
+}
+
+func (am *authManager) Evict(token string) {
+	if am.session.Remove(token) {
+		am.log.Debug("evict")
+	}
+}
+
+func (am *authManager) EvictUser(userId int64) {
+	for _, token := range am.session.Keys() {
+		if v, ok := am.session.Peek(token); ok && v.UserID == userId {
+			am.session.Remove(token)
+			am.log.With(wlog.Int64("user_id", userId)).Debug("evict")
+		}
+	}
 }
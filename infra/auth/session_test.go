@@ -0,0 +1,85 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/webitel/wlog"
+)
+
+func testManager(t *testing.T, sessions map[string]*Session) (*authManager, map[string]int) {
+	t.Helper()
+
+	am := NewAuthManager(10, time.Minute, time.Minute, "", wlog.NewLogger(&wlog.LoggerConfiguration{})).(*authManager)
+	calls := make(map[string]int)
+
+	am.fetch = func(_ context.Context, token string) (*Session, error) {
+		calls[token]++
+		if s, ok := sessions[token]; ok {
+			return s, nil
+		}
+
+		return nil, ErrStatusUnauthenticated
+	}
+
+	return am, calls
+}
+
+func TestAuthManager_GetSession(t *testing.T) {
+	ctx := context.Background()
+	am, calls := testManager(t, map[string]*Session{
+		"valid": {Token: "valid", UserID: 1},
+		"other": {Token: "other", UserID: 2},
+	})
+
+	for range 2 {
+		s, err := am.GetSession(ctx, "valid")
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), s.UserID)
+
+		_, err = am.GetSession(ctx, "invalid")
+		assert.ErrorIs(t, err, ErrStatusUnauthenticated)
+	}
+
+	assert.Equal(t, 1, calls["valid"])
+	assert.Equal(t, 1, calls["invalid"], "invalid token is denied from the cache")
+
+	am.Evict("valid")
+	_, err := am.GetSession(ctx, "valid")
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls["valid"])
+
+	_, err = am.GetSession(ctx, "other")
+	require.NoError(t, err)
+
+	EvictSession(am, &SessionEvent{UserId: 1})
+	assert.False(t, am.session.Contains("valid"))
+	assert.True(t, am.session.Contains("other"))
+}
+
+func TestParseSessionEvent(t *testing.T) {
+	e, err := ParseSessionEvent([]byte(`{"token": "abc", "user_id": 10}`), DefaultSessionEventFields)
+	require.NoError(t, err)
+	assert.Equal(t, &SessionEvent{Token: "abc", UserId: 10}, e)
+
+	e, err = ParseSessionEvent([]byte(`{"access_token": "abc", "uid": "10", "token": "other"}`),
+		SessionEventFields{Token: "access_token", UserId: "uid"})
+	require.NoError(t, err)
+	assert.Equal(t, &SessionEvent{Token: "abc", UserId: 10}, e)
+
+	_, err = ParseSessionEvent([]byte(`{"token": "abc"}`), SessionEventFields{Token: "access_token", UserId: "uid"})
+	assert.Error(t, err)
+
+	_, err = ParseSessionEvent([]byte(`{"user_id": "ten"}`), DefaultSessionEventFields)
+	assert.Error(t, err)
+
+	_, err = ParseSessionEvent([]byte(`{}`), DefaultSessionEventFields)
+	assert.Error(t, err)
+
+	_, err = ParseSessionEvent([]byte(`token`), DefaultSessionEventFields)
+	assert.Error(t, err)
+}
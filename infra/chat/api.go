@@ -3,15 +3,22 @@ package chat
 import (
 	"context"
 	"github.com/webitel/web-meeting-backend/gen/chat"
+	"github.com/webitel/web-meeting-backend/gen/chat/messages"
 	"github.com/webitel/web-meeting-backend/infra/grpc_client"
 	"github.com/webitel/wlog"
 )
 
 const ServiceName = "webitel.chat.server"
 
+const (
+	UserTypeWebChat = "webchat"
+	MessageTypeText = "text"
+)
+
 type Client struct {
-	api *grpc_client.Client[chat.ChatServiceClient]
-	log *wlog.Logger
+	api      *grpc_client.Client[chat.ChatServiceClient]
+	contacts *grpc_client.Client[messages.ContactLinkingServiceClient]
+	log      *wlog.Logger
 }
 
 func NewClient(consulTarget string, l *wlog.Logger) (*Client, error) {
@@ -20,22 +27,141 @@ func NewClient(consulTarget string, l *wlog.Logger) (*Client, error) {
 		return nil, err
 	}
 
+	contacts, err := grpc_client.NewClient[messages.ContactLinkingServiceClient](consulTarget, ServiceName, messages.NewContactLinkingServiceClient)
+	if err != nil {
+		return nil, err
+	}
+
 	return &Client{
-		api: api,
-		log: l,
+		api:      api,
+		contacts: contacts,
+		log:      l,
 	}, nil
 }
 
-func (c *Client) CloseChat(ctx context.Context, convId, closerId string, authUserId int64) error {
+// CloseChat closes the conversation with the named cause, e.g. "flow_end"; an unknown one is sent as no cause.
+func (c *Client) CloseChat(ctx context.Context, convId, closerId string, authUserId int64, cause string) error {
 	_, err := c.api.API.CloseConversation(ctx, &chat.CloseConversationRequest{
 		ConversationId:  convId,
 		CloserChannelId: closerId,
-		Cause:           0,
+		Cause:           chat.CloseConversationCause(chat.CloseConversationCause_value[cause]),
 		AuthUserId:      authUserId,
 	})
 	return err
 }
 
+// TransferChat transfers the conversation from the channel to the flow schema.
+func (c *Client) TransferChat(ctx context.Context, convId, channelId string, schemaId int64) error {
+	_, err := c.api.API.BlindTransfer(ctx, &chat.ChatTransferRequest{
+		ConversationId: convId,
+		ChannelId:      channelId,
+		SchemaId:       schemaId,
+	})
+	return err
+}
+
+// StartConversation opens the conversation of the external user connected via the chat gateway.
+// Returns the conversation id and the user channel id.
+func (c *Client) StartConversation(ctx context.Context, domainId int64, connection, username string, props map[string]string) (string, string, error) {
+	res, err := c.api.API.StartConversation(ctx, &chat.StartConversationRequest{
+		User: &chat.User{
+			Type:       UserTypeWebChat,
+			Connection: connection,
+		},
+		DomainId:   domainId,
+		Username:   username,
+		Properties: props,
+	})
+	if err != nil {
+		return "", "", err
+	}
+
+	return res.ConversationId, res.ChannelId, nil
+}
+
+// SendText sends the text message to the conversation from the channel.
+func (c *Client) SendText(ctx context.Context, conversationId, channelId, text string) (*chat.Message, error) {
+	res, err := c.api.API.SendMessage(ctx, &chat.SendMessageRequest{
+		Message: &chat.Message{
+			Type: MessageTypeText,
+			Text: text,
+		},
+		ChannelId:      channelId,
+		ConversationId: conversationId,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res.GetMessage(), nil
+}
+
+// SendServiceMessage posts the service text message into the conversation.
+func (c *Client) SendServiceMessage(ctx context.Context, conversationId, text string) error {
+	_, err := c.api.API.SendServiceMessage(ctx, &chat.SendServiceMessageRequest{
+		Message: &chat.Message{
+			Type: MessageTypeText,
+			Text: text,
+		},
+		ChatId: conversationId,
+	})
+
+	return err
+}
+
+// GetHistory returns the page of the conversation messages, newest first, and whether there are more.
+func (c *Client) GetHistory(ctx context.Context, conversationId string, page, size int32) ([]*chat.HistoryMessage, bool, error) {
+	res, err := c.api.API.GetHistoryMessages(ctx, &chat.GetHistoryMessagesRequest{
+		ConversationId: conversationId,
+		Sort:           []string{"-id"},
+		Page:           page,
+		Size:           size,
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	return res.Items, res.Next, nil
+}
+
+// GetConversation returns the conversation with its members.
+func (c *Client) GetConversation(ctx context.Context, id string) (*chat.Conversation, error) {
+	res, err := c.api.API.GetConversationByID(ctx, &chat.GetConversationByIDRequest{
+		Id: id,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res.GetItem(), nil
+}
+
+// CreateContactFromConversation creates the contact from the conversation data on behalf of the caller
+// and links it to the conversation client. Returns the contact id.
+func (c *Client) CreateContactFromConversation(ctx context.Context, token, conversationId, name, description string) (int64, error) {
+	res, err := c.contacts.API.CreateContactFromConversation(c.contacts.WithToken(ctx, token), &messages.CreateContactFromConversationRequest{
+		ConversationId: conversationId,
+		Name:           name,
+		Description:    description,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return res.GetId(), nil
+}
+
+// LinkContact links the existing contact to the conversation client.
+func (c *Client) LinkContact(ctx context.Context, conversationId, contactId string) error {
+	_, err := c.contacts.API.LinkContactToClientNA(ctx, &messages.LinkContactToClientNARequest{
+		ConversationId: conversationId,
+		ContactId:      contactId,
+	})
+
+	return err
+}
+
+// Close closes the connection shared by the chat and the contacts API.
 func (c *Client) Close() error {
 	return c.api.Close()
 }
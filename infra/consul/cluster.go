@@ -21,6 +21,7 @@ type Cluster struct {
 	name       string
 	discovery  *Consul
 	log        *wlog.Logger
+	check      CheckFunction
 }
 
 func NewCluster(name, consulAddr string, log *wlog.Logger) *Cluster {
@@ -31,14 +32,24 @@ func NewCluster(name, consulAddr string, log *wlog.Logger) *Cluster {
 	}
 }
 
+// SetCheck sets the health check reported to Consul; the service is healthy by default.
+func (c *Cluster) SetCheck(check CheckFunction) {
+	c.check = check
+}
+
 func (c *Cluster) Start(serviceInstanceID, host string, port int) error {
+	check := c.check
+	if check == nil {
+		check = func() error {
+			return nil
+		}
+	}
+
 	consulClient, err := newConsul(
 		serviceInstanceID,
 		c.consulAddr,
 		c.log,
-		func() error {
-			return nil
-		},
+		check,
 	)
 	if err != nil {
 		return err
@@ -2,6 +2,8 @@ package engine
 
 import (
 	"context"
+	"time"
+
 	"github.com/webitel/web-meeting-backend/gen/engine"
 	"github.com/webitel/web-meeting-backend/infra/grpc_client"
 	"github.com/webitel/wlog"
@@ -36,6 +38,134 @@ func (c *Client) SetVariables(ctx context.Context, domainId int64, callId string
 	return err
 }
 
+// Endpoint is the user or queue the call is originated to.
+type Endpoint struct {
+	Type string
+	Id   int64
+}
+
+// CreateCall originates the call on behalf of the domain to the endpoint, or to the destination number
+// when the endpoint is nil. Returns the call id.
+func (c *Client) CreateCall(ctx context.Context, domainId int64, to *Endpoint, destination string, vars map[string]string) (string, error) {
+	req := &engine.CreateCallRequest{
+		DomainId:    domainId,
+		Destination: destination,
+		Params: &engine.CreateCallRequest_CallSettings{
+			Variables: vars,
+		},
+	}
+
+	if to != nil {
+		req.To = &engine.CreateCallRequest_EndpointRequest{
+			Type: to.Type,
+			Id:   to.Id,
+		}
+	}
+
+	res, err := c.api.API.CreateCallNA(ctx, req)
+	if err != nil {
+		return "", err
+	}
+
+	return res.GetId(), nil
+}
+
+// SearchActiveCalls returns the ids of the active child calls of the parent call, with the caller token.
+func (c *Client) SearchActiveCalls(ctx context.Context, token string, domainId int64, parentId string) ([]string, error) {
+	res, err := c.api.API.SearchActiveCall(c.api.WithToken(ctx, token), &engine.SearchCallRequest{
+		DomainId: domainId,
+		ParentId: parentId,
+		Size:     100,
+		Fields:   []string{"id"},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(res.GetItems()))
+	for _, item := range res.GetItems() {
+		ids = append(ids, item.GetId())
+	}
+
+	return ids, nil
+}
+
+// HangupCall hangs up the call with the cause, with the caller token.
+func (c *Client) HangupCall(ctx context.Context, token string, domainId int64, callId, cause string) error {
+	_, err := c.api.API.HangupCall(c.api.WithToken(ctx, token), &engine.HangupCallRequest{
+		Id:       callId,
+		Cause:    cause,
+		DomainId: domainId,
+	})
+
+	return err
+}
+
+// SearchHistoryCalls returns the finished calls by id created since the time in milliseconds, with the caller token.
+func (c *Client) SearchHistoryCalls(ctx context.Context, token string, domainId int64, ids []string, since int64) ([]*engine.HistoryCall, error) {
+	res, err := c.api.API.SearchHistoryCall(c.api.WithToken(ctx, token), &engine.SearchHistoryCallRequest{
+		DomainId: domainId,
+		Id:       ids,
+		Size:     int32(len(ids)),
+		Sort:     "created_at",
+		CreatedAt: &engine.FilterBetween{
+			From: since,
+			To:   time.Now().UnixMilli(),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res.GetItems(), nil
+}
+
+// ReadCall returns the active call, with the caller token.
+func (c *Client) ReadCall(ctx context.Context, token string, domainId int64, callId string) (*engine.ActiveCall, error) {
+	return c.api.API.ReadCall(c.api.WithToken(ctx, token), &engine.ReadCallRequest{
+		Id:       callId,
+		DomainId: domainId,
+	})
+}
+
+func (c *Client) HoldCall(ctx context.Context, token string, domainId int64, callId string) error {
+	_, err := c.api.API.HoldCall(c.api.WithToken(ctx, token), &engine.UserCallRequest{
+		Id:       callId,
+		DomainId: domainId,
+	})
+
+	return err
+}
+
+func (c *Client) UnHoldCall(ctx context.Context, token string, domainId int64, callId string) error {
+	_, err := c.api.API.UnHoldCall(c.api.WithToken(ctx, token), &engine.UserCallRequest{
+		Id:       callId,
+		DomainId: domainId,
+	})
+
+	return err
+}
+
+func (c *Client) BlindTransferCall(ctx context.Context, token string, domainId int64, callId, destination string) error {
+	_, err := c.api.API.BlindTransferCall(c.api.WithToken(ctx, token), &engine.BlindTransferCallRequest{
+		Id:          callId,
+		Destination: destination,
+		DomainId:    domainId,
+	})
+
+	return err
+}
+
+func (c *Client) DtmfCall(ctx context.Context, token string, domainId int64, callId, digit string) error {
+	_, err := c.api.API.DtmfCall(c.api.WithToken(ctx, token), &engine.DtmfCallRequest{
+		Id:       callId,
+		Digit:    digit,
+		DomainId: domainId,
+	})
+
+	return err
+}
+
 func (c *Client) Close() error {
 	return c.api.Close()
 }
@@ -0,0 +1,47 @@
+package grpc_srv
+
+// Access is who may call the RPC.
+type Access int
+
+const (
+	// AccessUser requires the user session, the default of the RPCs missing in the policy.
+	AccessUser Access = iota
+	// AccessPublic allows the guest without a session; the user session is still read when sent.
+	AccessPublic
+	// AccessInternal requires the service token signed with the shared secret.
+	AccessInternal
+)
+
+func (a Access) String() string {
+	switch a {
+	case AccessPublic:
+		return "public"
+	case AccessInternal:
+		return "internal"
+	}
+
+	return "user"
+}
+
+// Policy is the access of the RPCs by the full method name, e.g. "/pkg.Service/Method".
+type Policy map[string]Access
+
+// Has reports whether any RPC of the policy has the access.
+func (p Policy) Has(access Access) bool {
+	for _, a := range p {
+		if a == access {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Access returns the access of the method, AccessUser when it is not listed.
+func (p Policy) Access(method string) Access {
+	if a, ok := p[method]; ok {
+		return a
+	}
+
+	return AccessUser
+}
@@ -2,6 +2,7 @@ package grpc_srv
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"os"
@@ -23,8 +24,12 @@ const RequestContextName = "grpc_ctx"
 
 type RequestContextSessionKey struct{}
 
+type RequestContextServiceKey struct{}
+
 var ErrUnauthenticated = status.Error(codes.Unauthenticated, "Unauthenticated")
 
+var ErrNoInternalSecret = errors.New("internal secret is required by the internal RPCs")
+
 type Server struct {
 	Addr string
 	host string
@@ -34,10 +39,15 @@ type Server struct {
 	listener net.Listener
 }
 
-// New provides a new gRPC server.
-func New(addr string, log *wlog.Logger, am auth.Manager) (*Server, error) {
+// New provides a new gRPC server which authenticates the callers by the access of the policy.
+// The internal RPCs of the policy require the signer with the shared secret.
+func New(addr string, log *wlog.Logger, am auth.Manager, signer *auth.ServiceSigner, policy Policy) (*Server, error) {
+	if policy.Has(AccessInternal) && !signer.Enabled() {
+		return nil, ErrNoInternalSecret
+	}
+
 	s := grpc.NewServer(grpc.ChainUnaryInterceptor(),
-		grpc.UnaryInterceptor(unaryInterceptor(am, log)))
+		grpc.UnaryInterceptor(unaryInterceptor(am, signer, policy, log)))
 
 	l, err := net.Listen("tcp", addr)
 	if err != nil {
@@ -124,26 +134,42 @@ func isPublicIP(IP net.IP) bool {
 	return true
 }
 
-func unaryInterceptor(am auth.Manager, log *wlog.Logger) grpc.UnaryServerInterceptor {
+func unaryInterceptor(am auth.Manager, signer *auth.ServiceSigner, policy Policy, log *wlog.Logger) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context,
 		req any,
 		info *grpc.UnaryServerInfo,
 		handler grpc.UnaryHandler,
 	) (any, error) {
 		start := time.Now()
+		access := policy.Access(info.FullMethod)
+		l := log.With(wlog.String("method", info.FullMethod), wlog.String("access", access.String()))
+
+		if access == AccessInternal {
+			service, err := getServiceFromCtx(signer, ctx, info.FullMethod)
+			if err != nil {
+				l.Warn("internal call rejected", wlog.Err(err))
+				return nil, ErrUnauthenticated
+			}
+
+			l = l.With(wlog.String("service", service))
+			ctx = context.WithValue(ctx, RequestContextServiceKey{}, service)
+		}
 
+		// the internal caller may forward the user session as well
 		_, session, err := getSessionFromCtx(am, ctx)
 		if err != nil {
-			log.Error(err.Error())
+			l.Error(err.Error())
 			return nil, err
 		}
 
+		if session == nil && access == AccessUser {
+			return nil, ErrUnauthenticated
+		}
+
 		ctx = context.WithValue(ctx, RequestContextSessionKey{}, session)
 
 		h, err := handler(ctx, req)
 
-		l := log.With(wlog.String("method", info.FullMethod))
-
 		if err != nil {
 			l.Error(err.Error(), wlog.Float64("duration_ms", float64(time.Since(start).Microseconds())/float64(1000)))
 		} else {
@@ -154,23 +180,41 @@ func unaryInterceptor(am auth.Manager, log *wlog.Logger) grpc.UnaryServerInterce
 	}
 }
 
+// requestMetadata returns the metadata of the gateway request context or the incoming one.
+func requestMetadata(ctx context.Context) (metadata.MD, bool) {
+	info, ok := ctx.Value(RequestContextName).(metadata.MD)
+
+	// todo
+	if !ok {
+		info, ok = metadata.FromIncomingContext(ctx)
+	}
+
+	return info, ok
+}
+
+// getServiceFromCtx returns the name of the internal caller from its token signed for the method.
+func getServiceFromCtx(signer *auth.ServiceSigner, ctx context.Context, method string) (string, error) {
+	info, ok := requestMetadata(ctx)
+	if !ok {
+		return "", auth.ErrInvalidServiceToken
+	}
+
+	token := info.Get(auth.ServiceTokenHeaderName)
+	if len(token) < 1 {
+		return "", auth.ErrInvalidServiceToken
+	}
+
+	return signer.Verify(token[0], method, time.Now())
+}
+
 func getSessionFromCtx(am auth.Manager, ctx context.Context) (metadata.MD, *auth.Session, error) {
 	var (
 		session *auth.Session
 		err     error
 		token   []string
-		info    metadata.MD
-		ok      bool
 	)
 
-	v := ctx.Value(RequestContextName)
-	info, ok = v.(metadata.MD)
-
-	// todo
-	if !ok {
-		info, ok = metadata.FromIncomingContext(ctx)
-	}
-
+	info, ok := requestMetadata(ctx)
 	if !ok {
 		return info, nil, ErrUnauthenticated
 	} else {
@@ -193,6 +237,30 @@ func getSessionFromCtx(am auth.Manager, ctx context.Context) (metadata.MD, *auth
 	return info, session, nil
 }
 
+// ServiceFromCtx returns the name of the internal caller of the AccessInternal RPC.
+func ServiceFromCtx(ctx context.Context) (string, error) {
+	service, ok := ctx.Value(RequestContextServiceKey{}).(string)
+	if !ok || service == "" {
+		return "", ErrUnauthenticated
+	}
+
+	return service, nil
+}
+
+// GuestTokenFromCtx returns the credential of the meeting participant, empty when it is not sent.
+func GuestTokenFromCtx(ctx context.Context) string {
+	info, ok := requestMetadata(ctx)
+	if !ok {
+		return ""
+	}
+
+	if token := info.Get(auth.GuestTokenHeaderName); len(token) > 0 {
+		return token[0]
+	}
+
+	return ""
+}
+
 func SessionFromCtx(ctx context.Context) (*auth.Session, error) {
 	sess, ok := ctx.Value(RequestContextSessionKey{}).(*auth.Session)
 
@@ -0,0 +1,97 @@
+package grpc_srv
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/webitel/wlog"
+
+	"github.com/webitel/web-meeting-backend/infra/auth"
+)
+
+type noSessions struct {
+	auth.Manager
+}
+
+func (noSessions) GetSession(context.Context, string) (*auth.Session, error) {
+	return nil, auth.ErrStatusUnauthenticated
+}
+
+func TestUnaryInterceptor(t *testing.T) {
+	signer := auth.NewServiceSigner("secret")
+	token, err := signer.Sign("flow_manager", "/svc/Internal", time.Now())
+	require.NoError(t, err)
+
+	policy := Policy{
+		"/svc/Public":   AccessPublic,
+		"/svc/Internal": AccessInternal,
+		"/svc/Other":    AccessInternal,
+	}
+	interceptor := unaryInterceptor(noSessions{}, signer, policy, wlog.NewLogger(&wlog.LoggerConfiguration{}))
+
+	handler := func(ctx context.Context, _ any) (any, error) {
+		if service, err := ServiceFromCtx(ctx); err == nil {
+			return service, nil
+		}
+
+		return "guest", nil
+	}
+
+	tests := []struct {
+		name    string
+		method  string
+		md      metadata.MD
+		want    any
+		wantErr bool
+	}{
+		{name: "public guest", method: "/svc/Public", md: metadata.MD{}, want: "guest"},
+		{name: "user without session", method: "/svc/User", md: metadata.MD{}, wantErr: true},
+		{name: "internal without token", method: "/svc/Internal", md: metadata.MD{}, wantErr: true},
+		{
+			name:    "internal with user token",
+			method:  "/svc/Internal",
+			md:      metadata.Pairs(auth.ServiceTokenHeaderName, "flow_manager:1:bad"),
+			wantErr: true,
+		},
+		{
+			name:   "internal with service token",
+			method: "/svc/Internal",
+			md:     metadata.Pairs(auth.ServiceTokenHeaderName, token),
+			want:   "flow_manager",
+		},
+		{
+			name:    "service token of another method",
+			method:  "/svc/Other",
+			md:      metadata.Pairs(auth.ServiceTokenHeaderName, token),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(context.Background(), tt.md)
+
+			res, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrUnauthenticated)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, res)
+		})
+	}
+}
+
+func TestNew_InternalSecretRequired(t *testing.T) {
+	policy := Policy{"/test.Service/Internal": AccessInternal}
+
+	_, err := New("127.0.0.1:0", wlog.NewLogger(&wlog.LoggerConfiguration{}), noSessions{}, auth.NewServiceSigner(""), policy)
+	require.ErrorIs(t, err, ErrNoInternalSecret)
+}
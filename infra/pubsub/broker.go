@@ -0,0 +1,105 @@
+package pubsub
+
+import (
+	"context"
+	"errors"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// Broker publishes messages and delivers them to the subscriptions.
+// Manager is the RabbitMQ implementation, MemoryBroker runs in process for tests.
+type Broker interface {
+	// Publish waits until the broker accepts the message;
+	// a message which can't be routed to any queue fails with ErrUnroutable.
+	Publish(ctx context.Context, exchange, key string, body []byte, headers Headers) error
+	Subscribe(spec SubscriptionSpec, h Handler) error
+	// Get fetches a single message from the queue; ok is false when the queue is empty.
+	Get(ctx context.Context, queue string) (msg *Message, ok bool, err error)
+}
+
+// Acknowledger settles the delivered message.
+type Acknowledger interface {
+	Ack() error
+	Nack(requeue bool) error
+}
+
+// Message is the delivered message; the handler must Ack or Nack it.
+type Message struct {
+	Exchange    string
+	RoutingKey  string
+	Headers     Headers
+	Body        []byte
+	Redelivered bool
+
+	// Value is the body decoded by the subscription OrderingKey, kept for the handler to not decode it again.
+	Value any
+
+	acknowledger Acknowledger
+}
+
+func (m *Message) Ack() error {
+	if m.acknowledger == nil {
+		return errors.New("message can't be acknowledged")
+	}
+
+	return m.acknowledger.Ack()
+}
+
+// Nack rejects the message: a requeued message is delivered again,
+// otherwise it is dead-lettered when the queue has a dead-letter exchange.
+func (m *Message) Nack(requeue bool) error {
+	if m.acknowledger == nil {
+		return errors.New("message can't be acknowledged")
+	}
+
+	return m.acknowledger.Nack(requeue)
+}
+
+type amqpAcknowledger struct {
+	delivery amqp.Delivery
+}
+
+func (a amqpAcknowledger) Ack() error {
+	return a.delivery.Ack(false)
+}
+
+func (a amqpAcknowledger) Nack(requeue bool) error {
+	return a.delivery.Nack(false, requeue)
+}
+
+func newAmqpMessage(d amqp.Delivery) *Message {
+	return &Message{
+		Exchange:     d.Exchange,
+		RoutingKey:   d.RoutingKey,
+		Headers:      Headers(d.Headers),
+		Body:         d.Body,
+		Redelivered:  d.Redelivered,
+		acknowledger: amqpAcknowledger{delivery: d},
+	}
+}
+
+// Publish sends the mandatory message through the manager channel, waiting for the broker confirmation.
+func (m *Manager) Publish(ctx context.Context, exchange, key string, body []byte, headers Headers) error {
+	return m.PublishAsync(ctx, Publishing{
+		Exchange:  exchange,
+		Key:       key,
+		Body:      body,
+		Headers:   headers,
+		Mandatory: true,
+	}).Wait(ctx)
+}
+
+// PublishAsync sends the message through the manager channel; many messages may wait for the confirmation at once.
+func (m *Manager) PublishAsync(ctx context.Context, msg Publishing) *Confirmation {
+	return m.Channel().PublishAsync(ctx, msg)
+}
+
+func (m *Manager) Get(_ context.Context, queue string) (*Message, bool, error) {
+	d, ok, err := m.Channel().Get(queue, false)
+	if err != nil || !ok {
+		return nil, ok, err
+	}
+
+	return newAmqpMessage(d), true, nil
+}
@@ -3,7 +3,6 @@ package pubsub
 import (
 	"context"
 	"errors"
-	"sync"
 
 	"github.com/google/uuid"
 	amqp "github.com/rabbitmq/amqp091-go"
@@ -28,11 +27,10 @@ type Exchange struct {
 }
 
 type Channel struct {
-	uuid           string
-	connection     *amqp.Connection
-	channel        *amqp.Channel
-	confirmPublish chan amqp.Confirmation
-	mtx            sync.Mutex
+	uuid       string
+	connection *amqp.Connection
+	channel    *amqp.Channel
+	publisher  *publisher
 }
 
 func newChannel(conn *amqp.Connection, prefetchCount int, prefetchGlobal bool, confirmPublish bool) (*Channel, error) {
@@ -66,15 +64,20 @@ func (r *Channel) Connect(prefetchCount int, prefetchGlobal bool, confirmPublish
 	}
 
 	if confirmPublish {
-		r.confirmPublish = r.channel.NotifyPublish(make(chan amqp.Confirmation, 1))
 		if err = r.channel.Confirm(false); err != nil {
 			return err
 		}
+		r.publisher = newPublisher(r.channel)
 	}
 
 	return nil
 }
 
+// Qos limits unacknowledged deliveries for the consumers started on the channel afterwards.
+func (r *Channel) Qos(prefetchCount int) error {
+	return r.channel.Qos(prefetchCount, 0, false)
+}
+
 func (r *Channel) Close() error {
 	if r.channel == nil {
 		return errors.New("channel is nil")
@@ -84,36 +87,39 @@ func (r *Channel) Close() error {
 }
 
 func (r *Channel) Publish(ctx context.Context, exchange, key string, body []byte) error {
-	if r.channel == nil {
-		return errors.New("channel is nil")
-	}
+	return r.PublishWithHeaders(ctx, exchange, key, body, nil)
+}
 
-	if r.confirmPublish != nil {
-		r.mtx.Lock()
-		defer r.mtx.Unlock()
-	}
+// PublishWithHeaders publishes the message with the headers, e.g. to keep the retry state of a redelivered message.
+func (r *Channel) PublishWithHeaders(ctx context.Context, exchange, key string, body []byte, headers Headers) error {
+	return r.PublishAsync(ctx, Publishing{
+		Exchange: exchange,
+		Key:      key,
+		Body:     body,
+		Headers:  headers,
+	}).Wait(ctx)
+}
 
-	message := amqp.Publishing{
-		ContentType: "text/json",
-		Body:        body,
+// PublishAsync publishes the message without waiting for the broker confirmation,
+// the result is reported by the returned Confirmation. Without confirm mode the
+// confirmation is resolved once the message is sent.
+func (r *Channel) PublishAsync(ctx context.Context, msg Publishing) *Confirmation {
+	if r.channel == nil {
+		return resolvedConfirmation(errors.New("channel is nil"))
 	}
 
-	if err := r.channel.PublishWithContext(ctx, exchange, key, false, false, message); err != nil {
-		return err
+	if r.publisher != nil {
+		return r.publisher.publish(ctx, msg)
 	}
 
-	if r.confirmPublish != nil {
-		confirmation, ok := <-r.confirmPublish
-		if !ok {
-			return errors.New("channel closed before could receive confirmation of publish")
-		}
+	err := r.channel.PublishWithContext(ctx, msg.Exchange, msg.Key, msg.Mandatory, false, amqp.Publishing{
+		ContentType:  "text/json",
+		DeliveryMode: amqp.Persistent,
+		Headers:      amqp.Table(msg.Headers),
+		Body:         msg.Body,
+	})
 
-		if !confirmation.Ack {
-			return errors.New("could not publish message, received nack from broker on confirmation")
-		}
-	}
-
-	return nil
+	return resolvedConfirmation(err)
 }
 
 func (r *Channel) DeclareExchange(ex Exchange) error {
@@ -188,6 +194,16 @@ func (r *Channel) ConsumeQueue(queue string, autoAck bool) (Delivery, error) {
 	)
 }
 
+// Get fetches a single message from the queue; ok is false when the queue is empty.
+func (r *Channel) Get(queue string, autoAck bool) (msg amqp.Delivery, ok bool, err error) {
+	return r.channel.Get(queue, autoAck)
+}
+
+// Cancel stops the channel consumer; the delivery channel is closed once the broker confirms it.
+func (r *Channel) Cancel() error {
+	return r.channel.Cancel(r.uuid, false)
+}
+
 func (r *Channel) BindQueue(queue, key, exchange string, args Headers) error {
 	return r.channel.QueueBind(
 		queue,            // name
@@ -0,0 +1,412 @@
+package pubsub
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+var _ Broker = (*Manager)(nil)
+var _ Broker = (*MemoryBroker)(nil)
+
+// MemoryBroker is the in-process Broker for tests. It routes by the exchange type like RabbitMQ,
+// and honours the queue arguments the service relies on: x-dead-letter-exchange,
+// x-dead-letter-routing-key, x-delivery-limit and x-message-ttl.
+// The messages of a queue are handled one by one, in the publishing order.
+type MemoryBroker struct {
+	mu        sync.Mutex
+	exchanges map[string]*memoryExchange
+	queues    map[string]*memoryQueue
+	pending   int
+	stop      chan struct{}
+	wg        sync.WaitGroup
+}
+
+type memoryExchange struct {
+	kind     MQExchangeType
+	bindings []Binding
+}
+
+type memoryQueue struct {
+	name     string
+	args     Headers
+	messages []*memoryMessage
+	notify   chan struct{}
+}
+
+type memoryMessage struct {
+	exchange   string
+	key        string
+	headers    Headers
+	body       []byte
+	deliveries int
+}
+
+type memoryAcknowledger struct {
+	broker  *MemoryBroker
+	queue   *memoryQueue
+	msg     *memoryMessage
+	settled bool
+}
+
+func NewMemoryBroker() *MemoryBroker {
+	return &MemoryBroker{
+		exchanges: make(map[string]*memoryExchange),
+		queues:    make(map[string]*memoryQueue),
+		stop:      make(chan struct{}),
+	}
+}
+
+// Declare creates the topology of the spec without consuming, e.g. for the queues filled by dead-lettering.
+func (b *MemoryBroker) Declare(spec SubscriptionSpec) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, ex := range spec.Exchanges {
+		if _, ok := b.exchanges[ex.Name]; !ok {
+			b.exchanges[ex.Name] = &memoryExchange{kind: ex.Type}
+		}
+	}
+
+	for _, q := range spec.Queues {
+		b.declareQueue(q.Name, q.Args)
+	}
+
+	b.declareQueue(spec.Queue, nil)
+
+	for _, bind := range spec.Bindings {
+		if ex, ok := b.exchanges[bind.Exchange]; ok {
+			ex.bindings = append(ex.bindings, bind)
+		}
+	}
+}
+
+func (b *MemoryBroker) Subscribe(spec SubscriptionSpec, h Handler) error {
+	b.Declare(spec)
+
+	b.mu.Lock()
+	q := b.queues[spec.Queue]
+	b.mu.Unlock()
+
+	b.wg.Add(1)
+	go func() {
+		defer b.wg.Done()
+		for {
+			msg, ack, ok := b.next(q)
+			if !ok {
+				return
+			}
+
+			h(context.Background(), msg)
+
+			// like a closed channel, return the message unsettled by the handler
+			ack.Nack(true)
+		}
+	}()
+
+	return nil
+}
+
+func (b *MemoryBroker) Publish(_ context.Context, exchange, key string, body []byte, headers Headers) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.publish(&memoryMessage{
+		exchange: exchange,
+		key:      key,
+		headers:  headers,
+		body:     body,
+	})
+}
+
+func (b *MemoryBroker) Get(_ context.Context, queue string) (*Message, bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	q, ok := b.queues[queue]
+	if !ok {
+		return nil, false, fmt.Errorf("queue %s not found", queue)
+	}
+
+	if len(q.messages) == 0 {
+		return nil, false, nil
+	}
+
+	msg, _ := b.pop(q)
+
+	return msg, true, nil
+}
+
+// Len returns the number of ready messages in the queue.
+func (b *MemoryBroker) Len(queue string) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if q, ok := b.queues[queue]; ok {
+		return len(q.messages)
+	}
+
+	return 0
+}
+
+// Wait blocks until every published message is settled, or a consumed queue holds it without a consumer.
+func (b *MemoryBroker) Wait(ctx context.Context, queues ...string) error {
+	ticker := time.NewTicker(time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		b.mu.Lock()
+		pending := b.pending
+		for _, name := range queues {
+			if q, ok := b.queues[name]; ok {
+				pending -= len(q.messages)
+			}
+		}
+		b.mu.Unlock()
+
+		if pending <= 0 {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%d messages not settled: %w", pending, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// Close stops the consumers.
+func (b *MemoryBroker) Close() {
+	close(b.stop)
+	b.wg.Wait()
+}
+
+func (b *MemoryBroker) declareQueue(name string, args Headers) {
+	if _, ok := b.queues[name]; ok {
+		return
+	}
+
+	b.queues[name] = &memoryQueue{
+		name:   name,
+		args:   args,
+		notify: make(chan struct{}, 1),
+	}
+}
+
+func (b *MemoryBroker) next(q *memoryQueue) (*Message, *memoryAcknowledger, bool) {
+	for {
+		b.mu.Lock()
+		if len(q.messages) > 0 {
+			msg, ack := b.pop(q)
+			b.mu.Unlock()
+			return msg, ack, true
+		}
+		b.mu.Unlock()
+
+		select {
+		case <-q.notify:
+		case <-b.stop:
+			return nil, nil, false
+		}
+	}
+}
+
+func (b *MemoryBroker) pop(q *memoryQueue) (*Message, *memoryAcknowledger) {
+	m := q.messages[0]
+	q.messages = q.messages[1:]
+
+	ack := &memoryAcknowledger{broker: b, queue: q, msg: m}
+
+	return &Message{
+		Exchange:     m.exchange,
+		RoutingKey:   m.key,
+		Headers:      m.headers,
+		Body:         m.body,
+		Redelivered:  m.deliveries > 0,
+		acknowledger: ack,
+	}, ack
+}
+
+// publish routes the message; the default exchange routes by the queue name.
+func (b *MemoryBroker) publish(m *memoryMessage) error {
+	if m.exchange == "" {
+		q, ok := b.queues[m.key]
+		if !ok {
+			return ErrUnroutable
+		}
+
+		b.enqueue(q, m)
+		return nil
+	}
+
+	ex, ok := b.exchanges[m.exchange]
+	if !ok {
+		return fmt.Errorf("exchange %s not found", m.exchange)
+	}
+
+	var routed bool
+	for _, bind := range ex.bindings {
+		if !ex.matches(bind.Key, m.key) {
+			continue
+		}
+
+		if q, ok := b.queues[bind.Queue]; ok {
+			c := *m
+			b.enqueue(q, &c)
+			routed = true
+		}
+	}
+
+	if !routed {
+		return ErrUnroutable
+	}
+
+	return nil
+}
+
+func (b *MemoryBroker) enqueue(q *memoryQueue, m *memoryMessage) {
+	b.pending++
+	q.messages = append(q.messages, m)
+
+	select {
+	case q.notify <- struct{}{}:
+	default:
+	}
+
+	if ttl := headerInt(q.args, "x-message-ttl"); ttl >= 0 {
+		time.AfterFunc(time.Duration(ttl)*time.Millisecond, func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+
+			for i, msg := range q.messages {
+				if msg == m {
+					q.messages = append(q.messages[:i], q.messages[i+1:]...)
+					b.deadLetter(q, m, "expired")
+					b.pending--
+					return
+				}
+			}
+		})
+	}
+}
+
+// deadLetter republishes the message to the queue dead-letter exchange, or drops it.
+func (b *MemoryBroker) deadLetter(q *memoryQueue, m *memoryMessage, reason string) {
+	dlx, ok := q.args["x-dead-letter-exchange"].(string)
+	if !ok {
+		return
+	}
+
+	key := m.key
+	if k, ok := q.args["x-dead-letter-routing-key"].(string); ok {
+		key = k
+	}
+
+	headers := Headers{}
+	for k, v := range m.headers {
+		headers[k] = v
+	}
+
+	// the latest death goes first, like RabbitMQ does
+	deaths, _ := headers["x-death"].([]any)
+	headers["x-death"] = append([]any{amqp.Table{
+		"queue":        q.name,
+		"reason":       reason,
+		"exchange":     m.exchange,
+		"routing-keys": []any{m.key},
+	}}, deaths...)
+
+	b.publish(&memoryMessage{
+		exchange: dlx,
+		key:      key,
+		headers:  headers,
+		body:     m.body,
+	})
+}
+
+func (a *memoryAcknowledger) Ack() error {
+	return a.settle(func() {})
+}
+
+func (a *memoryAcknowledger) Nack(requeue bool) error {
+	return a.settle(func() {
+		if !requeue {
+			a.broker.deadLetter(a.queue, a.msg, "rejected")
+			return
+		}
+
+		a.msg.deliveries++
+		if limit := headerInt(a.queue.args, "x-delivery-limit"); limit > 0 && a.msg.deliveries > limit {
+			a.broker.deadLetter(a.queue, a.msg, "delivery_limit")
+			return
+		}
+
+		a.broker.enqueue(a.queue, a.msg)
+	})
+}
+
+func (a *memoryAcknowledger) settle(fn func()) error {
+	a.broker.mu.Lock()
+	defer a.broker.mu.Unlock()
+
+	if a.settled {
+		return errors.New("message already settled")
+	}
+
+	a.settled = true
+	fn()
+	a.broker.pending--
+
+	return nil
+}
+
+func (ex *memoryExchange) matches(pattern, key string) bool {
+	switch ex.kind {
+	case ExchangeTypeFanout:
+		return true
+	case ExchangeTypeTopic:
+		return topicMatches(strings.Split(pattern, "."), strings.Split(key, "."))
+	default:
+		return pattern == key
+	}
+}
+
+// topicMatches matches the routing key words: "*" is exactly one word, "#" is zero or more.
+func topicMatches(pattern, key []string) bool {
+	if len(pattern) == 0 {
+		return len(key) == 0
+	}
+
+	switch pattern[0] {
+	case "#":
+		for i := 0; i <= len(key); i++ {
+			if topicMatches(pattern[1:], key[i:]) {
+				return true
+			}
+		}
+		return false
+	case "*":
+		return len(key) > 0 && topicMatches(pattern[1:], key[1:])
+	default:
+		return len(key) > 0 && pattern[0] == key[0] && topicMatches(pattern[1:], key[1:])
+	}
+}
+
+func headerInt(h Headers, name string) int {
+	switch v := h[name].(type) {
+	case int:
+		return v
+	case int32:
+		return int(v)
+	case int64:
+		return int(v)
+	}
+
+	return -1
+}
@@ -0,0 +1,123 @@
+package pubsub
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTopicMatches(t *testing.T) {
+	ex := &memoryExchange{kind: ExchangeTypeTopic}
+
+	assert.True(t, ex.matches("events.hangup.*.*.*", "events.hangup.1.2.call"))
+	assert.False(t, ex.matches("events.hangup.*.*.*", "events.ringing.1.2.call"))
+	assert.False(t, ex.matches("events.hangup.*", "events.hangup.1.2"))
+	assert.True(t, ex.matches("events.#", "events.hangup.1.2"))
+	assert.True(t, ex.matches("#", ""))
+}
+
+func TestMemoryBroker_Routing(t *testing.T) {
+	b := NewMemoryBroker()
+	defer b.Close()
+
+	var mu sync.Mutex
+	var keys []string
+
+	require.NoError(t, b.Subscribe(SubscriptionSpec{
+		Queue:     "q",
+		Exchanges: []Exchange{{Name: "ex", Type: ExchangeTypeTopic}},
+		Bindings:  []Binding{{Queue: "q", Exchange: "ex", Key: "a.*"}},
+	}, func(_ context.Context, msg *Message) {
+		mu.Lock()
+		keys = append(keys, msg.RoutingKey)
+		mu.Unlock()
+		msg.Ack()
+	}))
+
+	ctx := context.Background()
+	require.NoError(t, b.Publish(ctx, "ex", "a.1", nil, nil))
+	require.ErrorIs(t, b.Publish(ctx, "ex", "b.1", nil, nil), ErrUnroutable)
+	require.NoError(t, b.Publish(ctx, "", "q", nil, nil))
+	require.Error(t, b.Publish(ctx, "unknown", "a.1", nil, nil))
+
+	waitCtx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	require.NoError(t, b.Wait(waitCtx))
+
+	assert.Equal(t, []string{"a.1", "q"}, keys)
+}
+
+func TestMemoryBroker_DeadLetter(t *testing.T) {
+	b := NewMemoryBroker()
+	defer b.Close()
+
+	var deliveries int
+
+	b.Declare(SubscriptionSpec{
+		Queue:     "dead",
+		Exchanges: []Exchange{{Name: "dlx", Type: ExchangeTypeFanout}},
+		Bindings:  []Binding{{Queue: "dead", Exchange: "dlx"}},
+	})
+
+	require.NoError(t, b.Subscribe(SubscriptionSpec{
+		Queue: "q",
+		Queues: []Queue{{Name: "q", Args: Headers{
+			"x-delivery-limit":       2,
+			"x-dead-letter-exchange": "dlx",
+		}}},
+	}, func(_ context.Context, msg *Message) {
+		deliveries++
+		msg.Nack(true)
+	}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	require.NoError(t, b.Publish(ctx, "", "q", []byte("body"), nil))
+	require.NoError(t, b.Wait(ctx, "dead"))
+
+	assert.Equal(t, 3, deliveries)
+
+	msg, ok, err := b.Get(ctx, "dead")
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, []byte("body"), msg.Body)
+	assert.Equal(t, "q", msg.RoutingKey)
+	require.NoError(t, msg.Ack())
+	assert.Error(t, msg.Ack())
+}
+
+func TestMemoryBroker_MessageTTL(t *testing.T) {
+	b := NewMemoryBroker()
+	defer b.Close()
+
+	received := make(chan *Message, 1)
+
+	b.Declare(SubscriptionSpec{
+		Queue: "delay",
+		Queues: []Queue{{Name: "delay", Args: Headers{
+			"x-message-ttl":             int64(1),
+			"x-dead-letter-exchange":    "",
+			"x-dead-letter-routing-key": "q",
+		}}},
+	})
+
+	require.NoError(t, b.Subscribe(SubscriptionSpec{Queue: "q"}, func(_ context.Context, msg *Message) {
+		received <- msg
+		msg.Ack()
+	}))
+
+	require.NoError(t, b.Publish(context.Background(), "", "delay", []byte("body"), Headers{"k": "v"}))
+
+	select {
+	case msg := <-received:
+		assert.Equal(t, "v", msg.Headers["k"])
+		assert.NotNil(t, msg.Headers["x-death"])
+	case <-time.After(time.Second):
+		t.Fatal("expired message was not dead-lettered")
+	}
+}
@@ -0,0 +1,281 @@
+package pubsub
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/google/uuid"
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+var (
+	ErrUnroutable    = errors.New("message can't be routed to any queue")
+	ErrNacked        = errors.New("could not publish message, received nack from broker on confirmation")
+	ErrChannelClosed = errors.New("channel closed before could receive confirmation of publish")
+)
+
+// Publishing is the message to publish.
+type Publishing struct {
+	Exchange string
+	Key      string
+	Body     []byte
+	Headers  Headers
+	// Mandatory message which can't be routed to any queue is returned by the broker
+	// and fails with ErrUnroutable instead of being dropped silently.
+	Mandatory bool
+}
+
+// Confirmation is the future result of the asynchronous publishing.
+type Confirmation struct {
+	done chan struct{}
+	err  error
+}
+
+func newConfirmation() *Confirmation {
+	return &Confirmation{done: make(chan struct{})}
+}
+
+func resolvedConfirmation(err error) *Confirmation {
+	c := newConfirmation()
+	c.resolve(err)
+
+	return c
+}
+
+// Done is closed once the broker confirmed, rejected or returned the message.
+func (c *Confirmation) Done() <-chan struct{} {
+	return c.done
+}
+
+// Err returns the publishing result; it's valid only after Done is closed.
+func (c *Confirmation) Err() error {
+	return c.err
+}
+
+// Wait blocks until the publishing is confirmed; the context bounds only the waiting,
+// the message may still be delivered.
+func (c *Confirmation) Wait(ctx context.Context) error {
+	select {
+	case <-c.done:
+		return c.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// OnDone calls fn with the publishing result once it's known.
+func (c *Confirmation) OnDone(fn func(err error)) {
+	go func() {
+		<-c.done
+		fn(c.err)
+	}()
+}
+
+func (c *Confirmation) resolve(err error) {
+	c.err = err
+	close(c.done)
+}
+
+// notifyBuffer is the capacity of the confirm and return listeners, so the connection reader
+// rarely waits for the listener.
+const notifyBuffer = 256
+
+type pendingPublish struct {
+	confirmation *Confirmation
+	messageId    string
+	returned     bool
+}
+
+func (pp *pendingPublish) resolve(c amqp.Confirmation) {
+	switch {
+	case pp.returned:
+		pp.confirmation.resolve(ErrUnroutable)
+	case !c.Ack:
+		pp.confirmation.resolve(ErrNacked)
+	default:
+		pp.confirmation.resolve(nil)
+	}
+}
+
+// publisher tracks the outstanding publisher confirms of the channel by delivery tag,
+// so many messages can wait for the confirmation at once.
+type publisher struct {
+	// send publishes the message and returns its delivery tag
+	send func(ctx context.Context, msg Publishing, messageId string) (uint64, error)
+
+	mu        sync.Mutex
+	pending   map[uint64]*pendingPublish
+	byMessage map[string]uint64
+	// the confirms and returns received before the publishing is registered
+	confirmed map[uint64]amqp.Confirmation
+	returned  map[string]struct{}
+	closed    bool
+}
+
+func newPublisher(ch *amqp.Channel) *publisher {
+	p := newPendingPublisher(func(ctx context.Context, msg Publishing, messageId string) (uint64, error) {
+		dc, err := ch.PublishWithDeferredConfirmWithContext(ctx, msg.Exchange, msg.Key, msg.Mandatory, false, amqp.Publishing{
+			ContentType:  "text/json",
+			DeliveryMode: amqp.Persistent,
+			MessageId:    messageId,
+			Headers:      amqp.Table(msg.Headers),
+			Body:         msg.Body,
+		})
+		if err != nil {
+			return 0, err
+		}
+
+		return dc.DeliveryTag, nil
+	})
+
+	confirms := ch.NotifyPublish(make(chan amqp.Confirmation, notifyBuffer))
+	returns := ch.NotifyReturn(make(chan amqp.Return, notifyBuffer))
+
+	go p.listen(confirms, returns)
+
+	return p
+}
+
+func newPendingPublisher(send func(ctx context.Context, msg Publishing, messageId string) (uint64, error)) *publisher {
+	return &publisher{
+		send:      send,
+		pending:   make(map[uint64]*pendingPublish),
+		byMessage: make(map[string]uint64),
+		confirmed: make(map[uint64]amqp.Confirmation),
+		returned:  make(map[string]struct{}),
+	}
+}
+
+// publish sends the message without holding the lock: the library delivers the confirmations to listen
+// while holding its own lock, so listen must never wait for a publishing in progress. The confirmation
+// may therefore arrive before the publishing is registered.
+func (p *publisher) publish(ctx context.Context, msg Publishing) *Confirmation {
+	messageId := uuid.NewString()
+
+	p.mu.Lock()
+	closed := p.closed
+	p.mu.Unlock()
+
+	if closed {
+		return resolvedConfirmation(ErrChannelClosed)
+	}
+
+	tag, err := p.send(ctx, msg, messageId)
+	if err != nil {
+		return resolvedConfirmation(err)
+	}
+
+	return p.register(tag, messageId)
+}
+
+func (p *publisher) register(tag uint64, messageId string) *Confirmation {
+	c := newConfirmation()
+	pp := &pendingPublish{confirmation: c, messageId: messageId}
+
+	p.mu.Lock()
+	if _, ok := p.returned[messageId]; ok {
+		delete(p.returned, messageId)
+		pp.returned = true
+	}
+
+	if conf, ok := p.confirmed[tag]; ok {
+		delete(p.confirmed, tag)
+		p.mu.Unlock()
+		pp.resolve(conf)
+
+		return c
+	}
+
+	if p.closed {
+		p.mu.Unlock()
+		c.resolve(ErrChannelClosed)
+
+		return c
+	}
+
+	p.pending[tag] = pp
+	p.byMessage[messageId] = tag
+	p.mu.Unlock()
+
+	return c
+}
+
+func (p *publisher) listen(confirms <-chan amqp.Confirmation, returns <-chan amqp.Return) {
+	for {
+		select {
+		case ret, ok := <-returns:
+			if !ok {
+				returns = nil
+				continue
+			}
+			p.markReturned(ret.MessageId)
+
+		case c, ok := <-confirms:
+			if !ok {
+				p.failAll(ErrChannelClosed)
+				return
+			}
+			// the broker sends basic.return before basic.ack of the same message, so the buffered
+			// returns are handled before the confirmation
+			returns = p.drainReturns(returns)
+			p.confirm(c)
+		}
+	}
+}
+
+func (p *publisher) drainReturns(returns <-chan amqp.Return) <-chan amqp.Return {
+	for {
+		select {
+		case ret, ok := <-returns:
+			if !ok {
+				return nil
+			}
+			p.markReturned(ret.MessageId)
+		default:
+			return returns
+		}
+	}
+}
+
+func (p *publisher) markReturned(messageId string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if tag, ok := p.byMessage[messageId]; ok {
+		p.pending[tag].returned = true
+		return
+	}
+
+	p.returned[messageId] = struct{}{}
+}
+
+func (p *publisher) confirm(c amqp.Confirmation) {
+	p.mu.Lock()
+	pp, ok := p.pending[c.DeliveryTag]
+	if ok {
+		delete(p.pending, c.DeliveryTag)
+		delete(p.byMessage, pp.messageId)
+	} else {
+		p.confirmed[c.DeliveryTag] = c
+	}
+	p.mu.Unlock()
+
+	if ok {
+		pp.resolve(c)
+	}
+}
+
+func (p *publisher) failAll(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.closed = true
+	for tag, pp := range p.pending {
+		pp.confirmation.resolve(err)
+		delete(p.pending, tag)
+	}
+	p.byMessage = make(map[string]uint64)
+	p.confirmed = make(map[uint64]amqp.Confirmation)
+	p.returned = make(map[string]struct{})
+}
@@ -0,0 +1,159 @@
+package pubsub
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestPublisher(tags ...uint64) (*publisher, map[uint64]*Confirmation) {
+	p := newPendingPublisher(nil)
+
+	res := make(map[uint64]*Confirmation)
+	for _, tag := range tags {
+		c := newConfirmation()
+		id := string(rune('a' + tag))
+		p.pending[tag] = &pendingPublish{confirmation: c, messageId: id}
+		p.byMessage[id] = tag
+		res[tag] = c
+	}
+
+	return p, res
+}
+
+func TestPublisher_Confirm(t *testing.T) {
+	p, cs := newTestPublisher(1, 2, 3, 4)
+
+	p.markReturned(string(rune('a' + 3)))
+	p.confirm(amqp.Confirmation{DeliveryTag: 2, Ack: false})
+	p.confirm(amqp.Confirmation{DeliveryTag: 1, Ack: true})
+	p.confirm(amqp.Confirmation{DeliveryTag: 3, Ack: true})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	require.NoError(t, cs[1].Wait(ctx))
+	require.ErrorIs(t, cs[2].Wait(ctx), ErrNacked)
+	require.ErrorIs(t, cs[3].Wait(ctx), ErrUnroutable)
+
+	select {
+	case <-cs[4].Done():
+		t.Fatal("unconfirmed message resolved")
+	default:
+	}
+
+	p.failAll(ErrChannelClosed)
+	require.ErrorIs(t, cs[4].Wait(ctx), ErrChannelClosed)
+	require.Empty(t, p.pending)
+	require.ErrorIs(t, p.publish(ctx, Publishing{}).Wait(ctx), ErrChannelClosed)
+}
+
+func TestPublisher_ConfirmBeforeRegister(t *testing.T) {
+	p := newPendingPublisher(nil)
+
+	p.markReturned("returned")
+	p.confirm(amqp.Confirmation{DeliveryTag: 1, Ack: true})
+	p.confirm(amqp.Confirmation{DeliveryTag: 2, Ack: true})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	require.NoError(t, p.register(1, "acked").Wait(ctx))
+	require.ErrorIs(t, p.register(2, "returned").Wait(ctx), ErrUnroutable)
+	require.Empty(t, p.pending)
+	require.Empty(t, p.confirmed)
+	require.Empty(t, p.returned)
+}
+
+// TestPublisher_Concurrent publishes from many goroutines with a sender which, like the library, delivers
+// the confirmation to the listener while it holds its lock and before the publishing is registered.
+func TestPublisher_Concurrent(t *testing.T) {
+	const (
+		publishers = 32
+		messages   = 50
+	)
+
+	confirms := make(chan amqp.Confirmation)
+	returns := make(chan amqp.Return)
+
+	var (
+		mu  sync.Mutex
+		tag uint64
+	)
+
+	p := newPendingPublisher(func(_ context.Context, msg Publishing, messageId string) (uint64, error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		tag++
+		if msg.Mandatory {
+			returns <- amqp.Return{MessageId: messageId}
+		}
+		confirms <- amqp.Confirmation{DeliveryTag: tag, Ack: true}
+
+		return tag, nil
+	})
+	go p.listen(confirms, returns)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	type result struct {
+		unroutable bool
+		err        error
+	}
+
+	results := make(chan result, publishers*messages)
+	var wg sync.WaitGroup
+	for i := range publishers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range messages {
+				unroutable := (i+j)%7 == 0
+				results <- result{unroutable, p.publish(ctx, Publishing{Mandatory: unroutable}).Wait(ctx)}
+			}
+		}()
+	}
+	wg.Wait()
+	close(results)
+
+	for res := range results {
+		if res.unroutable {
+			require.ErrorIs(t, res.err, ErrUnroutable)
+		} else {
+			require.NoError(t, res.err)
+		}
+	}
+
+	close(confirms)
+}
+
+func TestConfirmation_OnDone(t *testing.T) {
+	c := newConfirmation()
+
+	res := make(chan error, 1)
+	c.OnDone(func(err error) {
+		res <- err
+	})
+
+	c.resolve(ErrNacked)
+
+	select {
+	case err := <-res:
+		require.ErrorIs(t, err, ErrNacked)
+	case <-time.After(time.Second):
+		t.Fatal("callback not called")
+	}
+}
+
+func TestConfirmation_WaitContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	require.ErrorIs(t, newConfirmation().Wait(ctx), context.Canceled)
+}
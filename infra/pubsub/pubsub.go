@@ -1,11 +1,13 @@
 package pubsub
 
 import (
-	"github.com/webitel/wlog"
+	"context"
 	"sync"
 	"time"
 
 	amqp "github.com/rabbitmq/amqp091-go"
+
+	"github.com/webitel/wlog"
 )
 
 type OnConnetFn func(channel *Channel) error
@@ -26,7 +28,8 @@ type Manager struct {
 	runningFetches  sync.WaitGroup
 	runningHandlers sync.WaitGroup
 
-	onConnect []OnConnetFn
+	onConnect     []OnConnetFn
+	subscriptions []*subscription
 }
 
 func New(log *wlog.Logger, address string) (*Manager, error) {
@@ -70,20 +73,38 @@ func (m *Manager) Start() error {
 	return m.connect()
 }
 
-// Shutdown stops the manager from fetching new messages and processing them.
-func (m *Manager) Shutdown() error {
-	// Once it's time to force-close tasks, cancel the base context.
+// Shutdown stops the manager from fetching new messages and waits until the dispatched ones
+// are processed, or the context is done, before closing the connection.
+func (m *Manager) Shutdown(ctx context.Context) error {
+	// Stop the reconnect loop and resubscribe attempts; no consumer or handler is started after it.
+	m.closeConn()
+
+	// Stop deliveries, the dispatched messages are still handled and the rest are requeued.
+	m.cancelSubscriptions()
+
+	drained := make(chan struct{})
 	go func() {
-		m.closeConn()
+		m.runningFetches.Wait()
+		m.runningHandlers.Wait()
+		close(drained)
 	}()
 
-	m.runningFetches.Wait()
+	select {
+	case <-drained:
+	case <-ctx.Done():
+		m.log.Warn("pubsub shutdown timed out, in-flight messages will be redelivered")
+	}
 
-	// Wait for running handlers to finish.
-	m.runningHandlers.Wait()
+	// Finally, close the connection to the PubSub provider.
+	m.mu.Lock()
+	conn := m.conn
+	m.mu.Unlock()
 
-	// Finally, close all connections to the PubSub providers.
-	m.closeConn()
+	if conn != nil && !conn.IsClosed() {
+		if err := conn.Close(); err != nil {
+			m.log.Error("failed to close pubsub connection", wlog.Err(err))
+		}
+	}
 
 	m.log.Debug("shutdown pubsub")
 
@@ -177,13 +198,15 @@ func (m *Manager) reconnect() {
 }
 
 func (m *Manager) tryConnect() error {
-	var err error
-
-	m.conn, err = amqp.Dial(m.address)
+	conn, err := amqp.Dial(m.address)
 	if err != nil {
 		return err
 	}
 
+	m.mu.Lock()
+	m.conn = conn
+	m.mu.Unlock()
+
 	m.channel, err = newChannel(m.conn, 0, false, true)
 	if err != nil {
 		return err
@@ -196,6 +219,8 @@ func (m *Manager) tryConnect() error {
 		}
 	}
 
+	m.startSubscriptions()
+
 	return nil
 }
 
@@ -211,3 +236,15 @@ func (m *Manager) closeConn() {
 		m.connected = false
 	}
 }
+
+func (m *Manager) isClosed() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	select {
+	case <-m.close:
+		return true
+	default:
+		return false
+	}
+}
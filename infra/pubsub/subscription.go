@@ -0,0 +1,395 @@
+package pubsub
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/webitel/wlog"
+)
+
+const (
+	defaultPrefetch = 64
+	defaultWorkers  = 1
+
+	resubscribeDelay = time.Second
+)
+
+// Queue is the rabbitmq queue declared for the subscription.
+type Queue struct {
+	Name    string
+	Args    Headers
+	Durable bool
+}
+
+// Binding routes the exchange messages matching the key to the queue.
+type Binding struct {
+	Queue    string
+	Exchange string
+	Key      string
+	Args     Headers
+}
+
+// SubscriptionSpec describes the consumed queue and the topology it depends on.
+// The topology is declared on every (re)connect before the consumption starts.
+type SubscriptionSpec struct {
+	Name      string
+	Queue     string
+	Exchanges []Exchange
+	Queues    []Queue
+	Bindings  []Binding
+
+	// Prefetch limits unacknowledged messages of the subscription.
+	Prefetch int
+	// Workers process messages concurrently; messages with the same OrderingKey
+	// are always handled by the same worker, in the delivery order. OrderingKey may keep
+	// the decoded body in Message.Value.
+	Workers     int
+	OrderingKey func(msg *Message) string
+}
+
+// Handler processes the message and settles it with Ack or Nack.
+type Handler func(ctx context.Context, msg *Message)
+
+// SubscriptionHealth is the consumer state reported by Manager.Health.
+type SubscriptionHealth struct {
+	Name      string
+	Queue     string
+	Consuming bool
+	InFlight  int64
+	Processed uint64
+	LastError string
+}
+
+type subscription struct {
+	spec    SubscriptionSpec
+	handler Handler
+	log     *wlog.Logger
+
+	mu        sync.Mutex
+	channel   *Channel
+	consuming bool
+	lastErr   error
+
+	inFlight  atomic.Int64
+	processed atomic.Uint64
+}
+
+// Subscribe registers the handler for the queue. The subscription survives reconnects:
+// its topology is declared and the consumption restarted on every new connection.
+func (m *Manager) Subscribe(spec SubscriptionSpec, h Handler) error {
+	if spec.Queue == "" {
+		return errors.New("subscription queue is required")
+	}
+
+	if spec.Name == "" {
+		spec.Name = spec.Queue
+	}
+
+	if spec.Prefetch < 1 {
+		spec.Prefetch = defaultPrefetch
+	}
+
+	if spec.Workers < 1 {
+		spec.Workers = defaultWorkers
+	}
+
+	s := &subscription{
+		spec:    spec,
+		handler: h,
+		log:     m.log.With(wlog.String("subscription", spec.Name)),
+	}
+
+	m.mu.Lock()
+	m.subscriptions = append(m.subscriptions, s)
+	connected := m.connected
+	m.mu.Unlock()
+
+	if connected {
+		return m.startSubscription(s)
+	}
+
+	return nil
+}
+
+// Health reports the consumers state; the error lists the subscriptions which don't consume.
+func (m *Manager) Health() ([]SubscriptionHealth, error) {
+	m.mu.Lock()
+	connected := m.connected
+	subs := append([]*subscription(nil), m.subscriptions...)
+	m.mu.Unlock()
+
+	var errs []error
+	if !connected {
+		errs = append(errs, errors.New("pubsub is not connected"))
+	}
+
+	res := make([]SubscriptionHealth, 0, len(subs))
+	for _, s := range subs {
+		h := s.health()
+		if !h.Consuming {
+			errs = append(errs, fmt.Errorf("subscription %s is not consuming: %s", h.Name, h.LastError))
+		}
+		res = append(res, h)
+	}
+
+	return res, errors.Join(errs...)
+}
+
+// Healthy returns nil when the manager is connected and every subscription consumes.
+func (m *Manager) Healthy() error {
+	_, err := m.Health()
+	return err
+}
+
+func (m *Manager) startSubscriptions() {
+	m.mu.Lock()
+	subs := append([]*subscription(nil), m.subscriptions...)
+	m.mu.Unlock()
+
+	for _, s := range subs {
+		if err := m.startSubscription(s); err != nil {
+			s.log.Error("failed to start subscription", wlog.Err(err))
+		}
+	}
+}
+
+func (m *Manager) startSubscription(s *subscription) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.consuming {
+		return nil
+	}
+
+	m.mu.Lock()
+	conn := m.conn
+	m.mu.Unlock()
+
+	channel, err := newChannel(conn, s.spec.Prefetch, false, false)
+	if err != nil {
+		s.lastErr = err
+		return err
+	}
+
+	if err = s.declare(channel); err != nil {
+		s.lastErr = err
+		channel.Close()
+		return err
+	}
+
+	delivery, err := channel.ConsumeQueue(s.spec.Queue, false)
+	if err != nil {
+		s.lastErr = err
+		channel.Close()
+		return err
+	}
+
+	if !m.track(&m.runningFetches) {
+		channel.Close()
+		return errors.New("pubsub is shut down")
+	}
+
+	s.channel = channel
+	s.consuming = true
+	s.lastErr = nil
+
+	go func() {
+		defer m.runningFetches.Done()
+		m.consume(s, channel, delivery)
+	}()
+
+	return nil
+}
+
+// consume dispatches the deliveries to the workers until the channel is closed or the consumer cancelled.
+func (m *Manager) consume(s *subscription, channel *Channel, delivery Delivery) {
+	var wg sync.WaitGroup
+	queues := make([]chan *Message, s.spec.Workers)
+
+	for i := range queues {
+		queues[i] = make(chan *Message, s.spec.Prefetch)
+		wg.Add(1)
+		go func(queue <-chan *Message) {
+			defer wg.Done()
+			for msg := range queue {
+				s.handle(msg)
+				m.runningHandlers.Done()
+			}
+		}(queues[i])
+	}
+
+	var next int
+	for d := range delivery {
+		msg := newAmqpMessage(d)
+
+		// the deliveries received once the shutdown started are returned to the queue
+		if !m.track(&m.runningHandlers) {
+			if err := msg.Nack(true); err != nil {
+				s.log.Warn("failed to requeue message on shutdown", wlog.Err(err))
+			}
+			continue
+		}
+
+		s.inFlight.Add(1)
+
+		i := next % len(queues)
+		next++
+		if s.spec.OrderingKey != nil {
+			i = workerIndex(s.spec.OrderingKey(msg), len(queues))
+		}
+
+		queues[i] <- msg
+	}
+
+	for _, queue := range queues {
+		close(queue)
+	}
+
+	wg.Wait()
+	channel.Close()
+
+	s.mu.Lock()
+	s.consuming = false
+	s.channel = nil
+	s.mu.Unlock()
+
+	if m.isClosed() {
+		return
+	}
+
+	s.log.Warn("subscription consumer stopped, resubscribing")
+	go m.resubscribe(s)
+}
+
+// track adds one to the wait group unless the manager is shut down. It runs under the lock Shutdown takes
+// to close the manager, so Shutdown never waits on the group while it grows.
+func (m *Manager) track(wg *sync.WaitGroup) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	select {
+	case <-m.close:
+		return false
+	default:
+	}
+
+	wg.Add(1)
+
+	return true
+}
+
+// resubscribe restarts the consumer closed apart from the connection, e.g. the channel exception.
+// A lost connection is handled by the reconnect loop, which restarts every subscription.
+func (m *Manager) resubscribe(s *subscription) {
+	for !m.isClosed() {
+		time.Sleep(resubscribeDelay)
+
+		m.mu.Lock()
+		connected := m.connected
+		m.mu.Unlock()
+
+		if !connected {
+			return
+		}
+
+		if err := m.startSubscription(s); err != nil {
+			s.log.Error("failed to resubscribe", wlog.Err(err))
+			continue
+		}
+
+		return
+	}
+}
+
+// cancelSubscriptions stops delivering new messages; the messages already received are still processed.
+func (m *Manager) cancelSubscriptions() {
+	m.mu.Lock()
+	subs := append([]*subscription(nil), m.subscriptions...)
+	m.mu.Unlock()
+
+	for _, s := range subs {
+		s.mu.Lock()
+		if s.channel != nil {
+			if err := s.channel.Cancel(); err != nil {
+				s.log.Warn("failed to cancel consumer", wlog.Err(err))
+			}
+		}
+		s.mu.Unlock()
+	}
+}
+
+func (s *subscription) declare(channel *Channel) error {
+	for _, ex := range s.spec.Exchanges {
+		if err := channel.DeclareExchange(ex); err != nil {
+			return fmt.Errorf("declare exchange %s: %w", ex.Name, err)
+		}
+	}
+
+	for _, q := range s.spec.Queues {
+		declare := channel.DeclareQueue
+		if q.Durable {
+			declare = channel.DeclareDurableQueue
+		}
+
+		if err := declare(q.Name, q.Args); err != nil {
+			return fmt.Errorf("declare queue %s: %w", q.Name, err)
+		}
+	}
+
+	for _, b := range s.spec.Bindings {
+		if err := channel.BindQueue(b.Queue, b.Key, b.Exchange, b.Args); err != nil {
+			return fmt.Errorf("bind queue %s to %s: %w", b.Queue, b.Exchange, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *subscription) handle(msg *Message) {
+	defer func() {
+		if r := recover(); r != nil {
+			s.log.Error(fmt.Sprintf("subscription handler panic: %v", r))
+			msg.Nack(false)
+		}
+
+		s.inFlight.Add(-1)
+		s.processed.Add(1)
+	}()
+
+	s.handler(context.Background(), msg)
+}
+
+func (s *subscription) health() SubscriptionHealth {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	h := SubscriptionHealth{
+		Name:      s.spec.Name,
+		Queue:     s.spec.Queue,
+		Consuming: s.consuming,
+		InFlight:  s.inFlight.Load(),
+		Processed: s.processed.Load(),
+	}
+
+	if s.lastErr != nil {
+		h.LastError = s.lastErr.Error()
+	}
+
+	return h
+}
+
+func workerIndex(key string, workers int) int {
+	if workers < 2 {
+		return 0
+	}
+
+	h := fnv.New32a()
+	h.Write([]byte(key))
+
+	return int(h.Sum32() % uint32(workers))
+}
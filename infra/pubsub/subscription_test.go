@@ -0,0 +1,34 @@
+package pubsub
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestManager_TrackStopsOnShutdown(t *testing.T) {
+	m := &Manager{close: make(chan bool)}
+
+	// handlers keep starting while the shutdown closes the manager and waits for them
+	var started sync.WaitGroup
+	for range 16 {
+		started.Add(1)
+		go func() {
+			defer started.Done()
+			for range 100 {
+				if !m.track(&m.runningHandlers) {
+					return
+				}
+				m.runningHandlers.Done()
+			}
+		}()
+	}
+
+	m.closeConn()
+	m.runningHandlers.Wait()
+	started.Wait()
+
+	assert.False(t, m.track(&m.runningHandlers))
+	assert.False(t, m.track(&m.runningFetches))
+}
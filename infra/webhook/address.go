@@ -0,0 +1,71 @@
+package webhook
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"syscall"
+)
+
+// ErrAddressDenied is returned for the receivers in the loopback, private and link-local networks,
+// so a domain can not make the service post to its own network.
+var ErrAddressDenied = errors.New("webhook address is not allowed")
+
+// sharedNetwork is the carrier-grade NAT range, RFC 6598, which is not covered by net.IP.IsPrivate.
+var sharedNetwork = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// CheckURL validates the receiver URL: the http or https scheme, and with private addresses denied
+// the host which is neither such a literal address nor localhost. The resolved address is checked on dial.
+func CheckURL(rawURL string, allowPrivate bool) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid URL format: %w", err)
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("URL scheme must be http or https")
+	}
+
+	host := u.Hostname()
+	if host == "" {
+		return fmt.Errorf("URL must have a host")
+	}
+
+	if allowPrivate {
+		return nil
+	}
+
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return fmt.Errorf("%w: %s", ErrAddressDenied, host)
+	}
+
+	if ip := net.ParseIP(host); ip != nil && deniedIP(ip) {
+		return fmt.Errorf("%w: %s", ErrAddressDenied, host)
+	}
+
+	return nil
+}
+
+func deniedIP(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() ||
+		ip.IsMulticast() || sharedNetwork.Contains(ip)
+}
+
+// dialControl rejects the connection to a denied address, whatever the host resolved to or was redirected to.
+func dialControl(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil || deniedIP(ip) {
+		return fmt.Errorf("%w: %s", ErrAddressDenied, host)
+	}
+
+	return nil
+}
@@ -0,0 +1,119 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	HeaderEvent     = "X-Webitel-Event"
+	HeaderDelivery  = "X-Webitel-Delivery"
+	HeaderTimestamp = "X-Webitel-Timestamp"
+	HeaderSignature = "X-Webitel-Signature"
+
+	signaturePrefix = "sha256="
+	userAgent       = "webitel-meetings-webhook/1.0"
+)
+
+type Request struct {
+	Url        string
+	Secret     string
+	Event      string
+	DeliveryId string
+	Body       []byte
+}
+
+type Client struct {
+	http         *http.Client
+	allowPrivate bool
+}
+
+// NewClient creates the client which, unless allowPrivate, refuses to connect to the loopback, private and
+// link-local addresses.
+func NewClient(timeout time.Duration, allowPrivate bool) *Client {
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if !allowPrivate {
+		dialer := &net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+			Control:   dialControl,
+		}
+		// a proxy would be dialed instead of the receiver, so the receiver address is checked by dialing it
+		transport.Proxy = nil
+		transport.DialContext = dialer.DialContext
+	}
+
+	return &Client{
+		http: &http.Client{
+			Timeout:   timeout,
+			Transport: transport,
+		},
+		allowPrivate: allowPrivate,
+	}
+}
+
+// CheckURL validates the receiver URL before the webhook is saved.
+func (c *Client) CheckURL(rawURL string) error {
+	return CheckURL(rawURL, c.allowPrivate)
+}
+
+// Send posts the signed body to the receiver and returns the response status code.
+// Any status outside of 2xx is reported as an error.
+func (c *Client) Send(ctx context.Context, r *Request) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.Url, bytes.NewReader(r.Body))
+	if err != nil {
+		return 0, err
+	}
+
+	ts := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set(HeaderEvent, r.Event)
+	req.Header.Set(HeaderDelivery, r.DeliveryId)
+	req.Header.Set(HeaderTimestamp, ts)
+	req.Header.Set(HeaderSignature, Sign(r.Secret, ts, r.Body))
+
+	res, err := c.http.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+
+	// drain the body so the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, 64<<10))
+
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		return res.StatusCode, fmt.Errorf("unexpected status %d", res.StatusCode)
+	}
+
+	return res.StatusCode, nil
+}
+
+// Sign returns the signature header value: HMAC-SHA256 of "<timestamp>.<body>" keyed by the secret.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks the signature header value produced by Sign.
+func Verify(secret, timestamp string, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
@@ -0,0 +1,123 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_Send(t *testing.T) {
+	const secret = "top-secret"
+	body := []byte(`{"event":"meeting.created"}`)
+
+	var received *http.Request
+	var receivedBody []byte
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		receivedBody, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	c := NewClient(time.Second, true)
+	code, err := c.Send(context.Background(), &Request{
+		Url:        srv.URL,
+		Secret:     secret,
+		Event:      "meeting.created",
+		DeliveryId: "1",
+		Body:       body,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, code)
+
+	require.NotNil(t, received)
+	assert.Equal(t, http.MethodPost, received.Method)
+	assert.Equal(t, "meeting.created", received.Header.Get(HeaderEvent))
+	assert.Equal(t, "1", received.Header.Get(HeaderDelivery))
+	assert.Equal(t, body, receivedBody)
+	assert.True(t, Verify(secret, received.Header.Get(HeaderTimestamp), receivedBody, received.Header.Get(HeaderSignature)))
+	assert.False(t, Verify("other", received.Header.Get(HeaderTimestamp), receivedBody, received.Header.Get(HeaderSignature)))
+}
+
+func TestClient_SendFailure(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	c := NewClient(time.Second, true)
+	code, err := c.Send(context.Background(), &Request{Url: srv.URL, Secret: "s", Body: []byte("{}")})
+	require.Error(t, err)
+	assert.Equal(t, http.StatusInternalServerError, code)
+}
+
+func TestClient_SendDenied(t *testing.T) {
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	// the test server listens on the loopback
+	c := NewClient(time.Second, false)
+	_, err := c.Send(context.Background(), &Request{Url: srv.URL, Secret: "s", Body: []byte("{}")})
+	require.ErrorIs(t, err, ErrAddressDenied)
+	assert.Zero(t, calls)
+}
+
+func TestCheckURL(t *testing.T) {
+	tests := []struct {
+		url          string
+		allowPrivate bool
+		wantErr      bool
+		denied       bool
+	}{
+		{url: "https://example.com/hook"},
+		{url: "http://93.184.216.34:8080/hook"},
+		{url: "ftp://example.com", wantErr: true},
+		{url: "https://", wantErr: true},
+		{url: "http://localhost/hook", wantErr: true, denied: true},
+		{url: "http://api.localhost./hook", wantErr: true, denied: true},
+		{url: "http://127.0.0.1/hook", wantErr: true, denied: true},
+		{url: "http://10.1.2.3/hook", wantErr: true, denied: true},
+		{url: "http://192.168.0.1/hook", wantErr: true, denied: true},
+		{url: "http://169.254.169.254/latest/meta-data", wantErr: true, denied: true},
+		{url: "http://100.64.0.1/hook", wantErr: true, denied: true},
+		{url: "http://0.0.0.0/hook", wantErr: true, denied: true},
+		{url: "http://[::1]/hook", wantErr: true, denied: true},
+		{url: "http://[fd00::1]/hook", wantErr: true, denied: true},
+		{url: "http://[::ffff:127.0.0.1]/hook", wantErr: true, denied: true},
+		{url: "http://10.1.2.3/hook", allowPrivate: true},
+		{url: "http://localhost/hook", allowPrivate: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			err := CheckURL(tt.url, tt.allowPrivate)
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+			assert.Equal(t, tt.denied, errors.Is(err, ErrAddressDenied))
+		})
+	}
+}
+
+func TestSign(t *testing.T) {
+	// echo -n "1700000000.{}" | openssl dgst -sha256 -hmac "secret"
+	assert.Equal(t,
+		"sha256=b8569b78799ff9e3cbff0fc2d63a33a2b57f3282abd07c37ae5e8e7d79a5f163",
+		Sign("secret", "1700000000", []byte("{}")),
+	)
+}
@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/webitel/wlog"
+
+	"github.com/webitel/web-meeting-backend/config"
+	"github.com/webitel/web-meeting-backend/infra/auth"
+	"github.com/webitel/web-meeting-backend/infra/pubsub"
+)
+
+// AuthEventsHandler evicts the cached sessions ended by the logout and revoked session events.
+type AuthEventsHandler struct {
+	log    *wlog.Logger
+	am     auth.Manager
+	fields auth.SessionEventFields
+}
+
+func NewAuthEventsHandler(cfg *config.Config, am auth.Manager, broker pubsub.Broker, l *wlog.Logger) (*AuthEventsHandler, error) {
+	h := &AuthEventsHandler{
+		log: l,
+		am:  am,
+		fields: auth.SessionEventFields{
+			Token:  cfg.Auth.EventsTokenField,
+			UserId: cfg.Auth.EventsUserField,
+		},
+	}
+
+	if cfg.Auth.EventsExchange == "" {
+		l.Warn("auth events exchange is not set, sessions are evicted by the cache ttl only")
+		return h, nil
+	}
+
+	if err := broker.Subscribe(h.subscription(cfg), h.handle); err != nil {
+		return nil, err
+	}
+
+	return h, nil
+}
+
+// subscription binds the own queue of the process, every instance evicts its cache. The queue name is random,
+// as the replicas may share the service id, and the auto-deleted queue is dropped with the process.
+func (h *AuthEventsHandler) subscription(cfg *config.Config) pubsub.SubscriptionSpec {
+	queue := fmt.Sprintf("meetings-auth.%s", uuid.NewString())
+
+	return pubsub.SubscriptionSpec{
+		Name:  "auth",
+		Queue: queue,
+		Exchanges: []pubsub.Exchange{
+			{Name: cfg.Auth.EventsExchange, Type: pubsub.ExchangeTypeTopic, Durable: true},
+		},
+		Queues: []pubsub.Queue{
+			{Name: queue},
+		},
+		Bindings: []pubsub.Binding{
+			{Queue: queue, Exchange: cfg.Auth.EventsExchange, Key: cfg.Auth.EventsKey},
+		},
+		Prefetch: 16,
+		Workers:  1,
+	}
+}
+
+func (h *AuthEventsHandler) handle(_ context.Context, msg *pubsub.Message) {
+	e, err := auth.ParseSessionEvent(msg.Body, h.fields)
+	if err != nil {
+		h.log.Error("failed to parse session event, dropping", wlog.Err(err), wlog.String("routing_key", msg.RoutingKey))
+		msg.Nack(false)
+		return
+	}
+
+	auth.EvictSession(h.am, e)
+	msg.Ack()
+}
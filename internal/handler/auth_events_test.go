@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/webitel/wlog"
+
+	"github.com/webitel/web-meeting-backend/config"
+	"github.com/webitel/web-meeting-backend/infra/auth"
+	"github.com/webitel/web-meeting-backend/infra/pubsub"
+)
+
+// evictingManager records the evicted tokens and users.
+type evictingManager struct {
+	auth.Manager
+
+	mu     sync.Mutex
+	tokens []string
+	users  []int64
+}
+
+func (m *evictingManager) Evict(token string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.tokens = append(m.tokens, token)
+}
+
+func (m *evictingManager) EvictUser(userId int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.users = append(m.users, userId)
+}
+
+func TestAuthEventsHandler(t *testing.T) {
+	broker := pubsub.NewMemoryBroker()
+	t.Cleanup(broker.Close)
+
+	cfg := &config.Config{
+		Service: config.Service{Id: "1"},
+		Auth: config.Auth{
+			EventsExchange:   "auth",
+			EventsKey:        "session.#",
+			EventsTokenField: "token",
+			EventsUserField:  "user_id",
+		},
+	}
+
+	// replicas with the same service id evict their caches on every event
+	am := &evictingManager{}
+	replica := &evictingManager{}
+	for _, m := range []auth.Manager{am, replica} {
+		_, err := NewAuthEventsHandler(cfg, m, broker, wlog.NewLogger(&wlog.LoggerConfiguration{EnableConsole: false}))
+		require.NoError(t, err)
+	}
+
+	ctx := context.Background()
+	require.NoError(t, broker.Publish(ctx, "auth", "session.logout", []byte(`{"token":"abc"}`), nil))
+	require.NoError(t, broker.Publish(ctx, "auth", "session.revoked", []byte(`{"user_id":7}`), nil))
+	require.NoError(t, broker.Publish(ctx, "auth", "session.revoked", []byte(`broken`), nil))
+
+	waitCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	require.NoError(t, broker.Wait(waitCtx))
+
+	for _, m := range []*evictingManager{am, replica} {
+		m.mu.Lock()
+		assert.Equal(t, []string{"abc"}, m.tokens)
+		assert.Equal(t, []int64{7}, m.users)
+		m.mu.Unlock()
+	}
+}
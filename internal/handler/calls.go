@@ -2,98 +2,327 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
+	"sync"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+
+	"github.com/webitel/wlog"
+
+	"github.com/webitel/web-meeting-backend/config"
 	"github.com/webitel/web-meeting-backend/infra/pubsub"
 	"github.com/webitel/web-meeting-backend/internal/model"
-	"github.com/webitel/wlog"
+	"github.com/webitel/web-meeting-backend/internal/service"
+)
+
+const (
+	callsExchange = "call"
+
+	CallsQueue           = "call-meetings"
+	CallsRetryQueue      = "call-meetings.retry"
+	CallsDeadLetterQueue = "call-meetings.dead-letter"
+	callsDeadLetterEx    = "call-meetings.dlx"
+
+	// headerRetryCount counts the processing attempts made through the retry queue.
+	headerRetryCount = "x-retry-count"
+	// headerRoutingKey keeps the original routing key, the retry queue republishes by the queue name.
+	headerRoutingKey = "x-original-routing-key"
+
+	defaultDeliveryLimit = 5
+	defaultPrefetch      = 64
+	defaultWorkers       = 8
 )
 
 type CallsHandler struct {
 	log    *wlog.Logger
 	svc    MeetingService
-	pubSub *pubsub.Manager
+	broker pubsub.Broker
+
+	deliveryLimit int
+	prefetch      int
+	workers       int
 }
 
-func NewCallsHandler(svc MeetingService, pubSub *pubsub.Manager, l *wlog.Logger) *CallsHandler {
+func NewCallsHandler(cfg *config.Config, svc MeetingService, broker pubsub.Broker, l *wlog.Logger) (*CallsHandler, error) {
 	ch := &CallsHandler{
-		svc:    svc,
-		pubSub: pubSub,
-		log:    l,
-	}
-	pubSub.AddOnConnect(func(channel *pubsub.Channel) error {
-		var err error
-		var delivery pubsub.Delivery
-		const exchange = "call"
-		const queueName = "call-meetings"
-
-		if err = channel.DeclareExchange(pubsub.Exchange{
-			Name:    exchange,
-			Type:    pubsub.ExchangeTypeTopic,
-			Durable: true,
-		}); err != nil {
-			return err
+		svc:           svc,
+		broker:        broker,
+		log:           l,
+		deliveryLimit: cfg.Pubsub.DeliveryLimit,
+		prefetch:      cfg.Pubsub.Prefetch,
+		workers:       cfg.Pubsub.Workers,
+	}
+
+	if ch.deliveryLimit < 1 {
+		ch.deliveryLimit = defaultDeliveryLimit
+	}
+
+	if ch.prefetch < 1 {
+		ch.prefetch = defaultPrefetch
+	}
+
+	if ch.workers < 1 {
+		ch.workers = defaultWorkers
+	}
+
+	if err := broker.Subscribe(ch.subscription(cfg.Pubsub.RetryDelay.Milliseconds()), ch.handle); err != nil {
+		return nil, err
+	}
+
+	return ch, nil
+}
+
+// subscription describes the call events queue with its retry queue and the dead-letter exchange.
+// A failed event waits in the retry queue, which expires it back to the main queue; an event which
+// can't be processed, or still fails after the delivery limit, is dead-lettered.
+// Events of one meeting always go to the same worker, so they are applied in order.
+func (ch *CallsHandler) subscription(retryDelayMs int64) pubsub.SubscriptionSpec {
+	spec := pubsub.SubscriptionSpec{
+		Name:  "calls",
+		Queue: CallsQueue,
+		Exchanges: []pubsub.Exchange{
+			{Name: callsExchange, Type: pubsub.ExchangeTypeTopic, Durable: true},
+			{Name: callsDeadLetterEx, Type: pubsub.ExchangeTypeFanout, Durable: true},
+		},
+		Queues: []pubsub.Queue{
+			{
+				Name:    CallsDeadLetterQueue,
+				Durable: true,
+				Args:    pubsub.Headers{"x-queue-type": "quorum"},
+			},
+			{
+				Name:    CallsQueue,
+				Durable: true,
+				Args: pubsub.Headers{
+					"x-queue-type":           "quorum",
+					"x-delivery-limit":       ch.deliveryLimit,
+					"x-dead-letter-exchange": callsDeadLetterEx,
+				},
+			},
+			{
+				Name:    CallsRetryQueue,
+				Durable: true,
+				Args: pubsub.Headers{
+					"x-queue-type":              "quorum",
+					"x-message-ttl":             retryDelayMs,
+					"x-dead-letter-exchange":    "",
+					"x-dead-letter-routing-key": CallsQueue,
+				},
+			},
+		},
+		Bindings: []pubsub.Binding{
+			{Queue: CallsDeadLetterQueue, Exchange: callsDeadLetterEx},
+		},
+		Prefetch:    ch.prefetch,
+		Workers:     ch.workers,
+		OrderingKey: newCallOrdering().key,
+	}
+
+	for _, event := range model.CallEvents {
+		spec.Bindings = append(spec.Bindings, pubsub.Binding{
+			Queue:    CallsQueue,
+			Exchange: callsExchange,
+			Key:      fmt.Sprintf("events.%s.*.*.*", event),
+			Args: pubsub.Headers{
+				"x-expires": 5 * 60 * 1000, // 5 minutes
+			},
+		})
+	}
+
+	return spec
+}
+
+func (ch *CallsHandler) handle(ctx context.Context, msg *pubsub.Message) {
+	c, err := callFromMessage(msg)
+	if err != nil {
+		ch.log.Error("failed to parse call, dead-lettering", wlog.Err(err))
+		msg.Nack(false)
+		return
+	}
+
+	id, err := ch.svc.ProcessCall(ctx, c)
+	if err == nil {
+		if id != "" {
+			ch.log.Debug(fmt.Sprintf("call [%s] %s; meeting_id: %s", c.Id, c.Event, id))
 		}
+		msg.Ack()
+		return
+	}
 
-		if err = channel.DeclareDurableQueue(queueName, pubsub.Headers{
-			"x-queue-type": "quorum",
-		}); err != nil {
-			return err
+	log := ch.log.With(wlog.String("call_id", c.Id), wlog.String("event", c.Event))
+
+	if errors.Is(err, service.ErrInvalidToken) || errors.Is(err, service.ErrCallInvalid) {
+		log.Error("failed to process call event, dead-lettering", wlog.Err(err))
+		msg.Nack(false)
+		return
+	}
+
+	attempt := retryCount(msg) + 1
+	if attempt >= ch.deliveryLimit {
+		log.Error(fmt.Sprintf("failed to process call event after %d attempts, dead-lettering", attempt), wlog.Err(err))
+		msg.Nack(false)
+		return
+	}
+
+	log.Warn(fmt.Sprintf("failed to process call event, attempt %d/%d", attempt, ch.deliveryLimit), wlog.Err(err))
+
+	if err = ch.broker.Publish(ctx, "", CallsRetryQueue, msg.Body, pubsub.Headers{
+		headerRetryCount: int32(attempt),
+		headerRoutingKey: originalRoutingKey(msg),
+	}); err != nil {
+		// the quorum queue delivery limit stops the requeue loop
+		log.Error("failed to schedule call event retry, requeue", wlog.Err(err))
+		msg.Nack(true)
+		return
+	}
+
+	msg.Ack()
+}
+
+// callFromMessage returns the event parsed by the ordering key, or parses the body.
+func callFromMessage(msg *pubsub.Message) (*model.Call, error) {
+	if c, ok := msg.Value.(*model.Call); ok {
+		return c, nil
+	}
+
+	return parseCall(msg)
+}
+
+func parseCall(msg *pubsub.Message) (*model.Call, error) {
+	c, err := model.CallFromJson(msg.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.Event == "" {
+		c.Event = eventFromRoutingKey(originalRoutingKey(msg))
+	}
+
+	return c, nil
+}
+
+// callOrdering keys the call events on the meeting, so the events of every call of one meeting
+// go to the same worker. The meeting id is reported on some events only: the event without it
+// is keyed on the meeting learnt from the earlier events of the call, or else on the customer call,
+// the parent of every agent leg.
+type callOrdering struct {
+	mu       sync.Mutex
+	meetings map[string]string // customer call id -> meeting id
+}
+
+// maxOrderedCalls bounds the known calls when their hangup events are lost.
+const maxOrderedCalls = 10000
+
+func newCallOrdering() *callOrdering {
+	return &callOrdering{meetings: make(map[string]string)}
+}
+
+// key parses the event once for the handler, see callFromMessage.
+func (o *callOrdering) key(msg *pubsub.Message) string {
+	c, err := parseCall(msg)
+	if err != nil {
+		return ""
+	}
+	msg.Value = c
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	callId := c.Id
+	if c.IsLeg() {
+		callId = *c.Data.ParentId
+	}
+
+	meetingId, ok := o.meetings[callId]
+	if c.Data.MeetingId != nil && *c.Data.MeetingId != "" {
+		meetingId, ok = *c.Data.MeetingId, true
+		if len(o.meetings) >= maxOrderedCalls {
+			clear(o.meetings)
+		}
+		o.meetings[callId] = meetingId
+	}
+
+	if !c.IsLeg() && c.Event == model.CallEventHangup {
+		delete(o.meetings, callId)
+	}
+
+	if ok {
+		return meetingId
+	}
+
+	return callId
+}
+
+// ReplayCallDeadLetters moves up to limit dead-lettered call events back to the calls queue
+// with a fresh retry budget. Returns the number of replayed events.
+func ReplayCallDeadLetters(ctx context.Context, broker pubsub.Broker, limit int) (int, error) {
+	var replayed int
+
+	for limit <= 0 || replayed < limit {
+		msg, ok, err := broker.Get(ctx, CallsDeadLetterQueue)
+		if err != nil {
+			return replayed, err
+		}
+
+		if !ok {
+			break
 		}
 
-		if err = channel.BindQueue(queueName, "events.hangup.*.*.*", exchange, pubsub.Headers{
-			"x-expires": 5 * 60 * 1000, // 5 minutes
+		if err = broker.Publish(ctx, "", CallsQueue, msg.Body, pubsub.Headers{
+			headerRoutingKey: originalRoutingKey(msg),
 		}); err != nil {
-			return err
+			msg.Nack(true)
+			return replayed, err
 		}
 
-		delivery, err = channel.ConsumeQueue(queueName, false)
-		if err != nil {
-			return err
+		if err = msg.Ack(); err != nil {
+			return replayed, err
 		}
 
-		go func() {
-			for {
-				select {
-				case msg, ok := <-delivery:
-					if !ok {
-						return
-					}
-
-					c, err := model.CallFromJson(msg.Body)
-					if err != nil {
-						l.Error("failed to parse call", wlog.Err(err))
-						msg.Ack(true)
-						continue
-					}
-
-					if c.Data.MeetingId == nil {
-						l.Debug(fmt.Sprintf("skip call [%s] without meeting id", c.Id))
-						msg.Ack(true)
-						continue
-					}
-
-					if c.Data.IsParent {
-						l.Debug(fmt.Sprintf("skip call [%s] meeting %s is parent", c.Id, *c.Data.MeetingId))
-						msg.Ack(true)
-						continue
-					}
-
-					ctx := context.Background()
-					var id string
-
-					id, err = svc.CloseByCall(ctx, *c.Data.MeetingId, c.Id, c.Data.TalkSec > 0)
-					if err != nil {
-						l.Error("failed to set call_id", wlog.Err(err))
-					}
-
-					l.Debug(fmt.Sprintf("call [%s] finished; meeting_id: %s", c.Id, id))
-					msg.Ack(true)
+		replayed++
+	}
+
+	return replayed, nil
+}
+
+func retryCount(msg *pubsub.Message) int {
+	switch v := msg.Headers[headerRetryCount].(type) {
+	case int32:
+		return int(v)
+	case int64:
+		return int(v)
+	}
+
+	return 0
+}
+
+func originalRoutingKey(msg *pubsub.Message) string {
+	if key, ok := msg.Headers[headerRoutingKey].(string); ok && key != "" {
+		return key
+	}
+
+	// a dead-lettered message keeps the routing keys it was published with
+	if deaths, ok := msg.Headers["x-death"].([]any); ok && len(deaths) > 0 {
+		if death, ok := deaths[len(deaths)-1].(amqp.Table); ok {
+			if keys, ok := death["routing-keys"].([]any); ok && len(keys) > 0 {
+				if key, ok := keys[0].(string); ok {
+					return key
 				}
 			}
-		}()
+		}
+	}
 
-		return nil
-	})
+	return msg.RoutingKey
+}
+
+// eventFromRoutingKey extracts the event name from the "events.<event>.<domain>.<user>.<call>" key.
+func eventFromRoutingKey(key string) string {
+	parts := strings.SplitN(key, ".", 3)
+	if len(parts) < 2 {
+		return ""
+	}
 
-	return ch
+	return parts[1]
 }
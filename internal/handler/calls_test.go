@@ -0,0 +1,289 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/webitel/wlog"
+
+	"github.com/webitel/web-meeting-backend/config"
+	"github.com/webitel/web-meeting-backend/infra/pubsub"
+	"github.com/webitel/web-meeting-backend/internal/model"
+	"github.com/webitel/web-meeting-backend/internal/service"
+)
+
+// callsMeetingService records the processed calls; process decides the result of each attempt.
+type callsMeetingService struct {
+	MeetingService
+
+	mu      sync.Mutex
+	calls   []*model.Call
+	process func(attempt int, c *model.Call) error
+}
+
+func (s *callsMeetingService) ProcessCall(_ context.Context, c *model.Call) (string, error) {
+	s.mu.Lock()
+	s.calls = append(s.calls, c)
+	attempt := len(s.calls)
+	s.mu.Unlock()
+
+	if s.process != nil {
+		if err := s.process(attempt, c); err != nil {
+			return "", err
+		}
+	}
+
+	return "meeting", nil
+}
+
+func (s *callsMeetingService) processed() []*model.Call {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]*model.Call(nil), s.calls...)
+}
+
+func setupCallsHandler(t *testing.T, svc *callsMeetingService) *pubsub.MemoryBroker {
+	t.Helper()
+
+	broker := pubsub.NewMemoryBroker()
+	t.Cleanup(broker.Close)
+
+	cfg := &config.Config{Pubsub: config.Pubsub{
+		DeliveryLimit: 3,
+		RetryDelay:    time.Millisecond,
+		Workers:       2,
+	}}
+
+	_, err := NewCallsHandler(cfg, svc, broker, wlog.NewLogger(&wlog.LoggerConfiguration{EnableConsole: false}))
+	require.NoError(t, err)
+
+	return broker
+}
+
+func publishHangup(t *testing.T, broker pubsub.Broker, body string) {
+	t.Helper()
+	require.NoError(t, broker.Publish(context.Background(), callsExchange, "events.hangup.1.2.call", []byte(body), nil))
+}
+
+func waitCalls(t *testing.T, broker *pubsub.MemoryBroker) {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, broker.Wait(ctx, CallsDeadLetterQueue))
+}
+
+const hangupBody = `{"id":"leg","data":"{\"meeting_id\":\"token\",\"parent_id\":\"call\",\"talk_sec\":\"12\"}"}`
+
+func TestCallsHandler_Hangup(t *testing.T) {
+	svc := &callsMeetingService{}
+	broker := setupCallsHandler(t, svc)
+
+	publishHangup(t, broker, hangupBody)
+	// not bound events are not consumed
+	err := broker.Publish(context.Background(), callsExchange, "events.missed.1.2.call", []byte(hangupBody), nil)
+	require.ErrorIs(t, err, pubsub.ErrUnroutable)
+	waitCalls(t, broker)
+
+	calls := svc.processed()
+	require.Len(t, calls, 1)
+	assert.Equal(t, model.CallEventHangup, calls[0].Event)
+	assert.Equal(t, "leg", calls[0].Id)
+	assert.Equal(t, "token", *calls[0].Data.MeetingId)
+	assert.Equal(t, 12, calls[0].Data.TalkSec)
+	assert.Zero(t, broker.Len(CallsDeadLetterQueue))
+}
+
+func TestCallsHandler_RetryTransientError(t *testing.T) {
+	svc := &callsMeetingService{process: func(attempt int, _ *model.Call) error {
+		if attempt == 1 {
+			return errors.New("database is down")
+		}
+		return nil
+	}}
+	broker := setupCallsHandler(t, svc)
+
+	publishHangup(t, broker, hangupBody)
+	waitCalls(t, broker)
+
+	calls := svc.processed()
+	require.Len(t, calls, 2)
+	// the retried event keeps the type from the original routing key
+	assert.Equal(t, model.CallEventHangup, calls[1].Event)
+	assert.Zero(t, broker.Len(CallsDeadLetterQueue))
+}
+
+func TestCallsHandler_DeadLetterAndReplay(t *testing.T) {
+	var fixed bool
+	svc := &callsMeetingService{process: func(_ int, _ *model.Call) error {
+		if !fixed {
+			return errors.New("chat is down")
+		}
+		return nil
+	}}
+	broker := setupCallsHandler(t, svc)
+
+	publishHangup(t, broker, hangupBody)
+	waitCalls(t, broker)
+
+	assert.Len(t, svc.processed(), 3)
+	require.Equal(t, 1, broker.Len(CallsDeadLetterQueue))
+
+	svc.mu.Lock()
+	fixed = true
+	svc.mu.Unlock()
+
+	n, err := ReplayCallDeadLetters(context.Background(), broker, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	waitCalls(t, broker)
+
+	calls := svc.processed()
+	require.Len(t, calls, 4)
+	assert.Equal(t, model.CallEventHangup, calls[3].Event)
+	assert.Zero(t, broker.Len(CallsDeadLetterQueue))
+}
+
+func TestCallsHandler_DeadLetterPoison(t *testing.T) {
+	svc := &callsMeetingService{process: func(_ int, _ *model.Call) error {
+		return fmt.Errorf("%w: bad", service.ErrInvalidToken)
+	}}
+	broker := setupCallsHandler(t, svc)
+
+	publishHangup(t, broker, `{`)
+	publishHangup(t, broker, hangupBody)
+	waitCalls(t, broker)
+
+	// the malformed event never reaches the service, the invalid token isn't retried
+	assert.Len(t, svc.processed(), 1)
+	assert.Equal(t, 2, broker.Len(CallsDeadLetterQueue))
+}
+
+func TestOriginalRoutingKey(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  *pubsub.Message
+		want string
+	}{
+		{
+			name: "Published by the engine",
+			msg:  &pubsub.Message{RoutingKey: "events.hangup.1.2.call"},
+			want: "events.hangup.1.2.call",
+		},
+		{
+			name: "Returned from the retry queue",
+			msg: &pubsub.Message{
+				RoutingKey: CallsQueue,
+				Headers:    pubsub.Headers{headerRoutingKey: "events.bridge.1.2.call"},
+			},
+			want: "events.bridge.1.2.call",
+		},
+		{
+			name: "Dead-lettered",
+			msg: &pubsub.Message{
+				RoutingKey: "",
+				Headers: pubsub.Headers{"x-death": []any{
+					amqp.Table{"routing-keys": []any{"events.hold.1.2.call"}},
+				}},
+			},
+			want: "events.hold.1.2.call",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key := originalRoutingKey(tt.msg)
+			assert.Equal(t, tt.want, key)
+		})
+	}
+
+	assert.Equal(t, "hold", eventFromRoutingKey("events.hold.1.2.call"))
+	assert.Empty(t, eventFromRoutingKey(CallsQueue))
+}
+
+func TestCallOrdering(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{
+			name: "Leg with meeting id",
+			body: `{"id":"leg","data":{"meeting_id":"token","parent_id":"parent"}}`,
+			want: "token",
+		},
+		{
+			name: "Agent leg",
+			body: `{"id":"leg","data":{"parent_id":"parent"}}`,
+			want: "parent",
+		},
+		{
+			name: "Customer call",
+			body: `{"id":"call"}`,
+			want: "call",
+		},
+		{
+			name: "Customer call with meeting id",
+			body: `{"id":"call","data":{"meeting_id":"token"}}`,
+			want: "token",
+		},
+		{
+			name: "Malformed",
+			body: `{`,
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, newCallOrdering().key(&pubsub.Message{Body: []byte(tt.body)}))
+		})
+	}
+
+	t.Run("Calls of one meeting share the key", func(t *testing.T) {
+		o := newCallOrdering()
+		bodies := []string{
+			`{"id":"call","event":"ringing","data":{"meeting_id":"token"}}`,
+			`{"id":"call","event":"active"}`,
+			`{"id":"leg","event":"bridge","data":{"parent_id":"call"}}`,
+			`{"id":"leg","event":"hangup","data":"{\"meeting_id\":\"token\",\"parent_id\":\"call\"}"}`,
+			`{"id":"call","event":"hangup"}`,
+			// the next call of the meeting
+			`{"id":"next","event":"ringing","data":{"meeting_id":"token"}}`,
+			`{"id":"next-leg","event":"active","data":{"parent_id":"next"}}`,
+		}
+
+		for _, body := range bodies {
+			assert.Equal(t, "token", o.key(&pubsub.Message{Body: []byte(body)}), body)
+		}
+
+		// the hangup of the customer call forgets it
+		assert.Equal(t, "call", o.key(&pubsub.Message{Body: []byte(`{"id":"call","event":"hold"}`)}))
+	})
+
+	t.Run("Parsed once", func(t *testing.T) {
+		msg := &pubsub.Message{Body: []byte(`{"id":"call"}`), Headers: pubsub.Headers{headerRoutingKey: "events.hold.1.2.call"}}
+		newCallOrdering().key(msg)
+
+		msg.Body = nil
+		c, err := callFromMessage(msg)
+		require.NoError(t, err)
+		assert.Equal(t, "call", c.Id)
+		assert.Equal(t, "hold", c.Event)
+	})
+}
+
+func TestRetryCount(t *testing.T) {
+	assert.Equal(t, 0, retryCount(&pubsub.Message{}))
+	assert.Equal(t, 2, retryCount(&pubsub.Message{Headers: pubsub.Headers{headerRetryCount: int32(2)}}))
+	assert.Equal(t, 3, retryCount(&pubsub.Message{Headers: pubsub.Headers{headerRetryCount: int64(3)}}))
+}
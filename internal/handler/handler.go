@@ -5,4 +5,6 @@ import "go.uber.org/fx"
 var Module = fx.Module("handler",
 	fx.Provide(NewMeetingHandler),
 	fx.Provide(NewCallsHandler),
+	fx.Provide(NewWebhookHandler),
+	fx.Provide(NewAuthEventsHandler),
 )
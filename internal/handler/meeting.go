@@ -2,7 +2,9 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"net/url"
 	"time"
 
@@ -12,16 +14,38 @@ import (
 	"github.com/webitel/wlog"
 
 	wmb "github.com/webitel/web-meeting-backend/gen/web-meeting-backend"
+	"github.com/webitel/web-meeting-backend/infra/auth"
 	"github.com/webitel/web-meeting-backend/infra/grpc_srv"
 	"github.com/webitel/web-meeting-backend/internal/model"
+	"github.com/webitel/web-meeting-backend/internal/service"
 )
 
 type MeetingService interface {
-	CreateMeeting(ctx context.Context, domainId int64, title string, expireSec int64, basePath string, vars map[string]string) (string, string, error)
+	CreateMeeting(ctx context.Context, domainId int64, title string, expireSec int64, basePath string, vars map[string]string, chat *model.MeetingChat) (string, *model.Meeting, error)
 	GetMeeting(ctx context.Context, id string) (*model.Meeting, error)
-	DeleteMeeting(ctx context.Context, id string) error
-	Satisfaction(ctx context.Context, meetingId, satisfaction string) error
-	CloseByCall(ctx context.Context, meetingId, callId string, bridged bool) (string, error)
+	DeleteMeeting(ctx context.Context, domainId int64, id string) error
+	Satisfaction(ctx context.Context, meetingId, guestToken, satisfaction string) error
+	JoinMeeting(ctx context.Context, meetingId, guestToken string) (*model.GuestCredential, error)
+	RevokeMeetingParticipant(ctx context.Context, domainId int64, meetingId, participantId string) error
+	ProcessCall(ctx context.Context, c *model.Call) (string, error)
+	StartMeetingCall(ctx context.Context, domainId int64, id string) (string, error)
+	EndMeeting(ctx context.Context, token string, domainId int64, id, cause string) error
+	GetMeetingDetails(ctx context.Context, token string, domainId int64, id string, withRecordings bool) (*model.MeetingDetails, error)
+	HoldMeetingCall(ctx context.Context, token string, domainId int64, id string) (string, error)
+	UnHoldMeetingCall(ctx context.Context, token string, domainId int64, id string) (string, error)
+	BlindTransferMeetingCall(ctx context.Context, token string, domainId int64, id, destination string) (string, error)
+	DtmfMeetingCall(ctx context.Context, token string, domainId int64, id, digits string) (string, error)
+	LinkMeetingContact(ctx context.Context, token string, domainId int64, id, contactId string) (string, error)
+	LinkConversation(ctx context.Context, id, conversationId string) (string, error)
+	SendMeetingMessage(ctx context.Context, id, guestToken, text string) (*model.ChatMessage, error)
+	MeetingMessages(ctx context.Context, id, guestToken string, page, size int) ([]*model.ChatMessage, bool, error)
+	OpenMeeting(ctx context.Context, id string) (*model.Meeting, error)
+	MeetingTranscript(ctx context.Context, domainId int64, id string, page, size int) (*model.Meeting, []*model.ChatMessage, bool, error)
+	ExportMeetingTranscript(ctx context.Context, domainId int64, id, format string) (*model.TranscriptExport, error)
+	GetChatPolicy(ctx context.Context, domainId int64) (*model.ChatPolicy, error)
+	UpdateChatPolicy(ctx context.Context, p *model.ChatPolicy) (*model.ChatPolicy, error)
+	CheckLicense(ctx context.Context, sess *auth.Session, domainId int64) error
+	MeetingUsage(ctx context.Context, domainId int64) (*model.MeetingUsage, error)
 }
 
 type MeetingHandler struct {
@@ -46,50 +70,59 @@ func (h *MeetingHandler) CreateMeeting(ctx context.Context, request *wmb.CreateM
 		return nil, err
 	}
 
+	if err = h.svc.CheckLicense(ctx, sess, sess.Domain(0)); err != nil {
+		return nil, h.meetingError("failed to create meeting", err)
+	}
+
 	if err = validateURL(request.BasePath); err != nil {
 		return nil, status.Error(codes.InvalidArgument, NewBadRequest("valid.base_path", err).Error())
 	}
 
-	id, url, err := h.svc.CreateMeeting(
+	id, meeting, err := h.svc.CreateMeeting(
 		ctx,
 		sess.Domain(0),
 		request.GetTitle(),
 		request.GetExpireSec(),
 		request.GetBasePath(),
 		request.GetVariables(),
+		toMeetingChat(request.GetChat()),
 	)
 	if err != nil {
-		h.log.Error("failed to create meeting", wlog.Err(err))
-
-		return nil, err
+		return nil, h.meetingError("failed to create meeting", err)
 	}
 
-	return &wmb.CreateMeetingResponse{
-		Id:  id,
-		Url: url,
-	}, nil
+	return toCreateMeetingResponse(id, meeting), nil
 }
 
 func (h *MeetingHandler) CreateMeetingNA(ctx context.Context, request *wmb.CreateMeetingRequest) (*wmb.CreateMeetingResponse, error) {
-	id, url, err := h.svc.CreateMeeting(ctx, request.DomainId, request.Title, request.ExpireSec, request.BasePath, request.Variables)
+	// the internal caller may forward the user session for the license, without it the license
+	// of the request domain is checked; the quota is taken by the service
+	sess, _ := grpc_srv.SessionFromCtx(ctx)
+	if err := h.svc.CheckLicense(ctx, sess, request.DomainId); err != nil {
+		return nil, h.meetingError("failed to create meeting", err)
+	}
+
+	id, meeting, err := h.svc.CreateMeeting(ctx, request.DomainId, request.Title, request.ExpireSec, request.BasePath, request.Variables,
+		toMeetingChat(request.Chat))
 	if err != nil {
-		h.log.Error("failed to create meeting", wlog.Err(err))
-		return nil, err
+		return nil, h.meetingError("failed to create meeting", err)
 	}
 
-	return &wmb.CreateMeetingResponse{
-		Id:  id,
-		Url: url,
-	}, nil
+	return toCreateMeetingResponse(id, meeting), nil
 }
 
 func (h *MeetingHandler) GetMeeting(ctx context.Context, request *wmb.GetMeetingRequest) (*wmb.Meeting, error) {
+	sess, err := grpc_srv.SessionFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	meeting, err := h.svc.GetMeeting(ctx, request.Id)
 	if err != nil {
 		h.log.Error("failed to get meeting", wlog.Err(err))
 		return nil, err
 	}
-	if meeting == nil {
+	if meeting == nil || meeting.DomainId != sess.Domain(0) {
 		return nil, status.Errorf(codes.NotFound, "not found")
 	}
 
@@ -101,25 +134,34 @@ func (h *MeetingHandler) GetMeeting(ctx context.Context, request *wmb.GetMeeting
 		return nil, status.Errorf(codes.Aborted, "bridged")
 	}
 
-	res := &wmb.Meeting{
-		Id:                request.Id, // todo, view internal id ?
-		Title:             meeting.Title,
-		CreatedAt:         meeting.CreatedAt,
-		ExpiresAt:         meeting.ExpiresAt,
-		Variables:         meeting.Variables,
-		Url:               meeting.Url,
-		AllowSatisfaction: meeting.AllowSatisfaction(),
+	return toMeeting(request.Id, meeting), nil
+}
+
+func (h *MeetingHandler) GetMeetingDetails(ctx context.Context, request *wmb.GetMeetingRequest) (*wmb.MeetingDetails, error) {
+	sess, err := grpc_srv.SessionFromCtx(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	if meeting.Satisfaction != nil {
-		res.Satisfaction = *meeting.Satisfaction
+	details, err := h.svc.GetMeetingDetails(ctx, sess.Token, sess.Domain(0), request.Id, sess.HasAction(auth.PermissionRecordFile))
+	if err != nil {
+		return nil, h.meetingError("failed to get meeting details", err)
+	}
+
+	res := &wmb.MeetingDetails{
+		Meeting: toMeeting(request.Id, details.Meeting),
+		Calls:   make([]*wmb.CallDetails, 0, len(details.Calls)),
+	}
+
+	for _, c := range details.Calls {
+		res.Calls = append(res.Calls, toCallDetails(c))
 	}
 
 	return res, nil
 }
 
 func (h *MeetingHandler) GetMeetingView(ctx context.Context, request *wmb.GetMeetingRequest) (*wmb.MeetingView, error) {
-	meeting, err := h.svc.GetMeeting(ctx, request.Id)
+	meeting, err := h.svc.OpenMeeting(ctx, request.Id)
 	if err != nil {
 		h.log.Error("failed to get meeting", wlog.Err(err))
 		return nil, err
@@ -137,6 +179,9 @@ func (h *MeetingHandler) GetMeetingView(ctx context.Context, request *wmb.GetMee
 		CreatedAt:         meeting.CreatedAt,
 		ExpiresAt:         meeting.ExpiresAt,
 		AllowSatisfaction: meeting.AllowSatisfaction(),
+		State:             string(meeting.State),
+		StateAt:           valueOf(meeting.StateAt),
+		Chat:              meeting.HasChat(),
 	}
 
 	if meeting.Satisfaction != nil {
@@ -147,24 +192,502 @@ func (h *MeetingHandler) GetMeetingView(ctx context.Context, request *wmb.GetMee
 }
 
 func (h *MeetingHandler) DeleteMeeting(ctx context.Context, request *wmb.DeleteMeetingRequest) (*wmb.DeleteMeetingResponse, error) {
-	err := h.svc.DeleteMeeting(ctx, request.Id)
+	sess, err := grpc_srv.SessionFromCtx(ctx)
 	if err != nil {
-		h.log.Error("failed to delete meeting", wlog.Err(err))
 		return nil, err
 	}
+
+	if err = h.svc.DeleteMeeting(ctx, sess.Domain(0), request.Id); err != nil {
+		return nil, h.meetingError("failed to delete meeting", err)
+	}
+
 	return &wmb.DeleteMeetingResponse{}, nil
 }
 
-func (h *MeetingHandler) SatisfactionMeeting(ctx context.Context, request *wmb.SatisfactionMeetingRequest) (*wmb.SatisfactionMeetingResponse, error) {
-	err := h.svc.Satisfaction(ctx, request.Id, request.Satisfaction)
+func (h *MeetingHandler) StartMeetingCall(ctx context.Context, request *wmb.StartMeetingCallRequest) (*wmb.StartMeetingCallResponse, error) {
+	sess, err := grpc_srv.SessionFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !sess.GetPermission(auth.ScopeCalls).CanCreate() {
+		return nil, status.Error(codes.PermissionDenied,
+			NewHttpError(http.StatusForbidden, "meeting.call.permission", "calls create permission required").Error())
+	}
+
+	callId, err := h.svc.StartMeetingCall(ctx, sess.Domain(0), request.Id)
+	if err != nil {
+		return nil, h.meetingError("failed to start meeting call", err)
+	}
+
+	return &wmb.StartMeetingCallResponse{
+		CallId: callId,
+	}, nil
+}
+
+func (h *MeetingHandler) EndMeeting(ctx context.Context, request *wmb.EndMeetingRequest) (*wmb.EndMeetingResponse, error) {
+	sess, err := grpc_srv.SessionFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = h.svc.EndMeeting(ctx, sess.Token, sess.Domain(0), request.Id, request.Cause); err != nil {
+		return nil, h.meetingError("failed to end meeting", err)
+	}
+
+	return &wmb.EndMeetingResponse{}, nil
+}
+
+func (h *MeetingHandler) HoldMeetingCall(ctx context.Context, request *wmb.MeetingCallRequest) (*wmb.MeetingCallResponse, error) {
+	sess, err := meetingCallSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	callId, err := h.svc.HoldMeetingCall(ctx, sess.Token, sess.Domain(0), request.Id)
+	if err != nil {
+		return nil, h.meetingError("failed to hold meeting call", err)
+	}
+
+	return &wmb.MeetingCallResponse{CallId: callId}, nil
+}
+
+func (h *MeetingHandler) UnHoldMeetingCall(ctx context.Context, request *wmb.MeetingCallRequest) (*wmb.MeetingCallResponse, error) {
+	sess, err := meetingCallSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	callId, err := h.svc.UnHoldMeetingCall(ctx, sess.Token, sess.Domain(0), request.Id)
+	if err != nil {
+		return nil, h.meetingError("failed to unhold meeting call", err)
+	}
+
+	return &wmb.MeetingCallResponse{CallId: callId}, nil
+}
+
+func (h *MeetingHandler) BlindTransferMeetingCall(ctx context.Context, request *wmb.BlindTransferMeetingCallRequest) (*wmb.MeetingCallResponse, error) {
+	sess, err := meetingCallSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	callId, err := h.svc.BlindTransferMeetingCall(ctx, sess.Token, sess.Domain(0), request.Id, request.Destination)
+	if err != nil {
+		return nil, h.meetingError("failed to transfer meeting call", err)
+	}
+
+	return &wmb.MeetingCallResponse{CallId: callId}, nil
+}
+
+func (h *MeetingHandler) DtmfMeetingCall(ctx context.Context, request *wmb.DtmfMeetingCallRequest) (*wmb.MeetingCallResponse, error) {
+	sess, err := meetingCallSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	callId, err := h.svc.DtmfMeetingCall(ctx, sess.Token, sess.Domain(0), request.Id, request.Digit)
+	if err != nil {
+		return nil, h.meetingError("failed to send dtmf to meeting call", err)
+	}
+
+	return &wmb.MeetingCallResponse{CallId: callId}, nil
+}
+
+// meetingCallSession returns the caller session allowed to control the calls.
+func meetingCallSession(ctx context.Context) (*auth.Session, error) {
+	sess, err := grpc_srv.SessionFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !sess.GetPermission(auth.ScopeCalls).CanUpdate() {
+		return nil, status.Error(codes.PermissionDenied,
+			NewHttpError(http.StatusForbidden, "meeting.call.permission", "calls update permission required").Error())
+	}
+
+	return sess, nil
+}
+
+func (h *MeetingHandler) LinkMeetingContact(ctx context.Context, request *wmb.LinkMeetingContactRequest) (*wmb.LinkMeetingContactResponse, error) {
+	sess, err := grpc_srv.SessionFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !sess.GetPermission(auth.ScopeContacts).CanUpdate() {
+		return nil, status.Error(codes.PermissionDenied,
+			NewHttpError(http.StatusForbidden, "meeting.contact.permission", "contacts update permission required").Error())
+	}
+
+	contactId, err := h.svc.LinkMeetingContact(ctx, sess.Token, sess.Domain(0), request.Id, request.ContactId)
+	if err != nil {
+		return nil, h.meetingError("failed to link meeting contact", err)
+	}
+
+	return &wmb.LinkMeetingContactResponse{ContactId: contactId}, nil
+}
+
+func (h *MeetingHandler) LinkMeetingConversationNA(ctx context.Context, request *wmb.LinkMeetingConversationRequest) (*wmb.LinkMeetingConversationResponse, error) {
+	convId, err := h.svc.LinkConversation(ctx, request.Id, request.ConversationId)
+	if err != nil {
+		return nil, h.meetingError("failed to link meeting conversation", err)
+	}
+
+	return &wmb.LinkMeetingConversationResponse{ConversationId: convId}, nil
+}
+
+func (h *MeetingHandler) SendMeetingMessage(ctx context.Context, request *wmb.SendMeetingMessageRequest) (*wmb.MeetingMessage, error) {
+	msg, err := h.svc.SendMeetingMessage(ctx, request.Id, grpc_srv.GuestTokenFromCtx(ctx), request.Text)
+	if err != nil {
+		return nil, h.meetingError("failed to send meeting message", err)
+	}
+
+	return toMeetingMessage(msg), nil
+}
+
+func (h *MeetingHandler) SearchMeetingMessages(ctx context.Context, request *wmb.SearchMeetingMessagesRequest) (*wmb.ListMeetingMessage, error) {
+	list, next, err := h.svc.MeetingMessages(ctx, request.Id, grpc_srv.GuestTokenFromCtx(ctx), int(request.GetPage()), int(request.GetSize()))
+	if err != nil {
+		return nil, h.meetingError("failed to search meeting messages", err)
+	}
+
+	res := &wmb.ListMeetingMessage{
+		Page:  request.GetPage(),
+		Next:  next,
+		Items: make([]*wmb.MeetingMessage, 0, len(list)),
+	}
+
+	for _, m := range list {
+		res.Items = append(res.Items, toMeetingMessage(m))
+	}
+
+	return res, nil
+}
+
+func (h *MeetingHandler) GetMeetingTranscript(ctx context.Context, request *wmb.GetMeetingTranscriptRequest) (*wmb.MeetingTranscript, error) {
+	sess, err := grpc_srv.SessionFromCtx(ctx)
 	if err != nil {
-		h.log.Error("failed to satisfaction", wlog.Err(err))
 		return nil, err
 	}
 
+	meeting, list, next, err := h.svc.MeetingTranscript(ctx, sess.Domain(0), request.Id, int(request.GetPage()), int(request.GetSize()))
+	if err != nil {
+		return nil, h.meetingError("failed to get meeting transcript", err)
+	}
+
+	res := &wmb.MeetingTranscript{
+		Meeting: toMeeting(request.Id, meeting),
+		Page:    request.GetPage(),
+		Next:    next,
+		Items:   make([]*wmb.MeetingMessage, 0, len(list)),
+	}
+
+	for _, m := range list {
+		res.Items = append(res.Items, toMeetingMessage(m))
+	}
+
+	return res, nil
+}
+
+func (h *MeetingHandler) ExportMeetingTranscript(ctx context.Context, request *wmb.ExportMeetingTranscriptRequest) (*wmb.MeetingTranscriptFile, error) {
+	sess, err := grpc_srv.SessionFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := h.svc.ExportMeetingTranscript(ctx, sess.Domain(0), request.Id, request.Format)
+	if err != nil {
+		return nil, h.meetingError("failed to export meeting transcript", err)
+	}
+
+	return &wmb.MeetingTranscriptFile{
+		Name:        file.FileName,
+		ContentType: file.ContentType,
+		Data:        file.Data,
+	}, nil
+}
+
+func (h *MeetingHandler) GetMeetingChatPolicy(ctx context.Context, _ *wmb.GetMeetingChatPolicyRequest) (*wmb.MeetingChatPolicy, error) {
+	sess, err := settingSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := h.svc.GetChatPolicy(ctx, sess.Domain(0))
+	if err != nil {
+		return nil, h.meetingError("failed to get meeting chat policy", err)
+	}
+
+	return toChatPolicy(p), nil
+}
+
+func (h *MeetingHandler) UpdateMeetingChatPolicy(ctx context.Context, request *wmb.UpdateMeetingChatPolicyRequest) (*wmb.MeetingChatPolicy, error) {
+	sess, err := settingSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := h.svc.UpdateChatPolicy(ctx, &model.ChatPolicy{
+		DomainId: sess.Domain(0),
+		Mode:     model.ChatCloseMode(request.Mode),
+		Cause:    request.Cause,
+		DelaySec: int(request.DelaySec),
+		SchemaId: request.SchemaId,
+	})
+	if err != nil {
+		return nil, h.meetingError("failed to update meeting chat policy", err)
+	}
+
+	return toChatPolicy(p), nil
+}
+
+// settingSession returns the caller session allowed to change the domain settings.
+func settingSession(ctx context.Context) (*auth.Session, error) {
+	sess, err := grpc_srv.SessionFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !sess.HasAction(auth.PermissionSystemSetting) {
+		return nil, status.Error(codes.PermissionDenied,
+			NewHttpError(http.StatusForbidden, "meeting.permission", "system_setting permission required").Error())
+	}
+
+	return sess, nil
+}
+
+func (h *MeetingHandler) GetMeetingUsage(ctx context.Context, _ *wmb.GetMeetingUsageRequest) (*wmb.MeetingUsage, error) {
+	sess, err := grpc_srv.SessionFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	usage, err := h.svc.MeetingUsage(ctx, sess.Domain(0))
+	if err != nil {
+		return nil, h.meetingError("failed to get meeting usage", err)
+	}
+
+	return &wmb.MeetingUsage{
+		Active:    int32(usage.Active),
+		Today:     int32(usage.Today),
+		MaxActive: int32(usage.Quota.MaxActive),
+		MaxDaily:  int32(usage.Quota.MaxDaily),
+		License:   usage.Quota.License,
+	}, nil
+}
+
+func (h *MeetingHandler) SatisfactionMeeting(ctx context.Context, request *wmb.SatisfactionMeetingRequest) (*wmb.SatisfactionMeetingResponse, error) {
+	err := h.svc.Satisfaction(ctx, request.Id, grpc_srv.GuestTokenFromCtx(ctx), request.Satisfaction)
+	if err != nil {
+		return nil, h.meetingError("failed to satisfaction", err)
+	}
+
 	return &wmb.SatisfactionMeetingResponse{}, nil
 }
 
+func (h *MeetingHandler) JoinMeeting(ctx context.Context, request *wmb.JoinMeetingRequest) (*wmb.GuestCredential, error) {
+	c, err := h.svc.JoinMeeting(ctx, request.Id, grpc_srv.GuestTokenFromCtx(ctx))
+	if err != nil {
+		return nil, h.meetingError("failed to join meeting", err)
+	}
+
+	return &wmb.GuestCredential{
+		ParticipantId: c.ParticipantId,
+		Token:         c.Token,
+		ExpiresAt:     c.ExpiresAt,
+	}, nil
+}
+
+func (h *MeetingHandler) RevokeMeetingParticipant(ctx context.Context, request *wmb.RevokeMeetingParticipantRequest) (*wmb.RevokeMeetingParticipantResponse, error) {
+	sess, err := meetingCallSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = h.svc.RevokeMeetingParticipant(ctx, sess.Domain(0), request.Id, request.ParticipantId); err != nil {
+		return nil, h.meetingError("failed to revoke meeting participant", err)
+	}
+
+	return &wmb.RevokeMeetingParticipantResponse{}, nil
+}
+
+func (h *MeetingHandler) meetingError(msg string, err error) error {
+	switch {
+	case errors.Is(err, service.ErrMeetingNotFound), errors.Is(err, service.ErrInvalidToken):
+		return status.Error(codes.NotFound, NewHttpError(http.StatusNotFound, "meeting.not_found", err.Error()).Error())
+	case errors.Is(err, service.ErrMeetingClosed):
+		return status.Error(codes.FailedPrecondition, NewHttpError(http.StatusConflict, "meeting.closed", err.Error()).Error())
+	case errors.Is(err, service.ErrMeetingNoChat):
+		return status.Error(codes.NotFound, NewHttpError(http.StatusNotFound, "meeting.chat.not_found", err.Error()).Error())
+	case errors.Is(err, service.ErrMeetingInvalid):
+		return status.Error(codes.InvalidArgument, NewBadRequest("valid.meeting", err).Error())
+	case errors.Is(err, service.ErrGuestInvalid):
+		return status.Error(codes.Unauthenticated, NewHttpError(http.StatusUnauthorized, "meeting.guest.invalid", err.Error()).Error())
+	case errors.Is(err, service.ErrMeetingQuota):
+		return status.Error(codes.ResourceExhausted, NewHttpError(http.StatusTooManyRequests, "meeting.quota", err.Error()).Error())
+	case errors.Is(err, service.ErrMeetingLicense):
+		return status.Error(codes.PermissionDenied, NewHttpError(http.StatusForbidden, "meeting.license", err.Error()).Error())
+	}
+
+	h.log.Error(msg, wlog.Err(err))
+
+	return err
+}
+
+func toChatPolicy(p *model.ChatPolicy) *wmb.MeetingChatPolicy {
+	return &wmb.MeetingChatPolicy{
+		Mode:      string(p.Mode),
+		Cause:     p.Cause,
+		DelaySec:  int32(p.DelaySec),
+		SchemaId:  p.SchemaId,
+		UpdatedAt: p.UpdatedAt,
+	}
+}
+
+func toMeetingChat(opts *wmb.MeetingChatOptions) *model.MeetingChat {
+	if opts == nil {
+		return nil
+	}
+
+	return &model.MeetingChat{
+		Connection: opts.Connection,
+		Username:   opts.Username,
+	}
+}
+
+func toCreateMeetingResponse(id string, meeting *model.Meeting) *wmb.CreateMeetingResponse {
+	return &wmb.CreateMeetingResponse{
+		Id:             id,
+		Url:            meeting.Url,
+		ConversationId: valueOf(meeting.ConversationId),
+	}
+}
+
+func toMeetingMessage(m *model.ChatMessage) *wmb.MeetingMessage {
+	res := &wmb.MeetingMessage{
+		Id:        m.Id,
+		Type:      m.Type,
+		Text:      m.Text,
+		Customer:  m.Customer,
+		CreatedAt: m.CreatedAt,
+	}
+
+	if f := m.File; f != nil {
+		res.File = &wmb.MeetingMessageFile{
+			Id:   f.Id,
+			Url:  f.Url,
+			Mime: f.Mime,
+			Name: f.Name,
+			Size: f.Size,
+		}
+	}
+
+	return res
+}
+
+func toMeeting(id string, meeting *model.Meeting) *wmb.Meeting {
+	res := &wmb.Meeting{
+		Id:                id, // todo, view internal id ?
+		Title:             meeting.Title,
+		CreatedAt:         meeting.CreatedAt,
+		ExpiresAt:         meeting.ExpiresAt,
+		Variables:         meeting.Variables,
+		Url:               meeting.Url,
+		AllowSatisfaction: meeting.AllowSatisfaction(),
+		State:             string(meeting.State),
+		RingingAt:         valueOf(meeting.RingingAt),
+		AnsweredAt:        valueOf(meeting.AnsweredAt),
+		BridgedAt:         valueOf(meeting.BridgedAt),
+		EndedAt:           valueOf(meeting.EndedAt),
+		AnswerSec:         meeting.AnswerSec(),
+		Outcome:           string(valueOf(meeting.Outcome)),
+		TalkSec:           meeting.TalkSec(),
+		Attempts:          int32(meeting.Attempts()),
+		Calls:             meetingCalls(meeting.Calls),
+		ConversationId:    valueOf(meeting.ConversationId),
+		ContactId:         valueOf(meeting.ContactId),
+	}
+
+	if meeting.Satisfaction != nil {
+		res.Satisfaction = *meeting.Satisfaction
+	}
+
+	return res
+}
+
+func toCallDetails(c *model.CallDetails) *wmb.CallDetails {
+	res := &wmb.CallDetails{
+		Id:          c.Id,
+		ParentId:    c.ParentId,
+		Active:      c.Active,
+		Direction:   c.Direction,
+		Destination: c.Destination,
+		User:        toLookup(c.User),
+		Agent:       toLookup(c.Agent),
+		Queue:       toLookup(c.Queue),
+		CreatedAt:   c.CreatedAt,
+		AnsweredAt:  c.AnsweredAt,
+		BridgedAt:   c.BridgedAt,
+		HangupAt:    c.HangupAt,
+		Duration:    c.Duration,
+		WaitSec:     c.WaitSec,
+		HoldSec:     c.HoldSec,
+		TalkSec:     c.TalkSec,
+		Cause:       c.Cause,
+		HangupBy:    c.HangupBy,
+	}
+
+	for _, f := range c.Recordings {
+		res.Recordings = append(res.Recordings, &wmb.CallRecording{
+			Id:       f.Id,
+			Name:     f.Name,
+			MimeType: f.MimeType,
+			Size:     f.Size,
+			StartAt:  f.StartAt,
+			StopAt:   f.StopAt,
+		})
+	}
+
+	return res
+}
+
+func toLookup(l *model.Lookup) *wmb.Lookup {
+	if l == nil {
+		return nil
+	}
+
+	return &wmb.Lookup{
+		Id:   l.Id,
+		Name: l.Name,
+	}
+}
+
+func meetingCalls(calls []*model.MeetingCall) []*wmb.MeetingCall {
+	res := make([]*wmb.MeetingCall, 0, len(calls))
+	for _, c := range calls {
+		res = append(res, &wmb.MeetingCall{
+			Id:        c.CallId,
+			ParentId:  valueOf(c.ParentId),
+			Cause:     valueOf(c.Cause),
+			TalkSec:   int64(c.TalkSec),
+			StartedAt: c.StartedAt,
+			EndedAt:   valueOf(c.EndedAt),
+		})
+	}
+
+	return res
+}
+
+func valueOf[T any](v *T) T {
+	if v == nil {
+		var zero T
+		return zero
+	}
+
+	return *v
+}
+
 func validateURL(rawURL string) error {
 	u, err := url.ParseRequestURI(rawURL)
 	if err != nil {
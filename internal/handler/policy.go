@@ -0,0 +1,23 @@
+package handler
+
+import (
+	wmb "github.com/webitel/web-meeting-backend/gen/web-meeting-backend"
+	"github.com/webitel/web-meeting-backend/infra/grpc_srv"
+)
+
+// AccessPolicy lists the RPCs which are not for the signed in users only.
+// Every other RPC, including a new one, requires the user session.
+var AccessPolicy = grpc_srv.Policy{
+	// the meeting page of the customer, addressed by the meeting token; the participant actions
+	// also require the guest credential issued by JoinMeeting
+	wmb.MeetingService_GetMeetingView_FullMethodName:        grpc_srv.AccessPublic,
+	wmb.MeetingService_JoinMeeting_FullMethodName:           grpc_srv.AccessPublic,
+	wmb.MeetingService_SatisfactionMeeting_FullMethodName:   grpc_srv.AccessPublic,
+	wmb.MeetingService_SendMeetingMessage_FullMethodName:    grpc_srv.AccessPublic,
+	wmb.MeetingService_SearchMeetingMessages_FullMethodName: grpc_srv.AccessPublic,
+
+	// other webitel services, e.g. the flow, create meetings of any domain
+	wmb.MeetingService_CreateMeetingNA_FullMethodName: grpc_srv.AccessInternal,
+	// the flow of the chat widget links the conversation it started for the meeting
+	wmb.MeetingService_LinkMeetingConversationNA_FullMethodName: grpc_srv.AccessInternal,
+}
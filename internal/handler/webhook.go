@@ -0,0 +1,229 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/webitel/wlog"
+
+	wmb "github.com/webitel/web-meeting-backend/gen/web-meeting-backend"
+	"github.com/webitel/web-meeting-backend/infra/auth"
+	"github.com/webitel/web-meeting-backend/infra/grpc_srv"
+	"github.com/webitel/web-meeting-backend/internal/model"
+	"github.com/webitel/web-meeting-backend/internal/service"
+)
+
+type WebhookService interface {
+	Create(ctx context.Context, w *model.Webhook) (string, error)
+	Get(ctx context.Context, domainId, id int64) (*model.Webhook, error)
+	List(ctx context.Context, domainId int64, page, size int) ([]*model.Webhook, bool, error)
+	Update(ctx context.Context, w *model.Webhook, enabled *bool) (*model.Webhook, error)
+	Delete(ctx context.Context, domainId, id int64) error
+	ListDeliveries(ctx context.Context, domainId, webhookId int64, page, size int) ([]*model.WebhookDelivery, bool, error)
+}
+
+type WebhookHandler struct {
+	log *wlog.Logger
+	svc WebhookService
+	wmb.UnimplementedWebhookServiceServer
+}
+
+func NewWebhookHandler(svc WebhookService, s *grpc_srv.Server, l *wlog.Logger) *WebhookHandler {
+	h := &WebhookHandler{
+		svc: svc,
+		log: l,
+	}
+	wmb.RegisterWebhookServiceServer(s, h)
+
+	return h
+}
+
+func (h *WebhookHandler) CreateWebhook(ctx context.Context, request *wmb.CreateWebhookRequest) (*wmb.Webhook, error) {
+	sess, err := webhookSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = validateURL(request.GetUrl()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, NewBadRequest("valid.webhook.url", err).Error())
+	}
+
+	w := &model.Webhook{
+		DomainId: sess.Domain(0),
+		Url:      request.GetUrl(),
+		Events:   request.GetEvents(),
+		Secret:   request.GetSecret(),
+	}
+
+	secret, err := h.svc.Create(ctx, w)
+	if err != nil {
+		return nil, h.webhookError("failed to create webhook", err)
+	}
+
+	res := toWebhook(w)
+	res.Secret = secret
+
+	return res, nil
+}
+
+func (h *WebhookHandler) SearchWebhook(ctx context.Context, request *wmb.SearchWebhookRequest) (*wmb.ListWebhook, error) {
+	sess, err := webhookSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	list, next, err := h.svc.List(ctx, sess.Domain(0), int(request.GetPage()), int(request.GetSize()))
+	if err != nil {
+		return nil, h.webhookError("failed to search webhooks", err)
+	}
+
+	res := &wmb.ListWebhook{
+		Page:  request.GetPage(),
+		Next:  next,
+		Items: make([]*wmb.Webhook, 0, len(list)),
+	}
+
+	for _, w := range list {
+		res.Items = append(res.Items, toWebhook(w))
+	}
+
+	return res, nil
+}
+
+func (h *WebhookHandler) ReadWebhook(ctx context.Context, request *wmb.ReadWebhookRequest) (*wmb.Webhook, error) {
+	sess, err := webhookSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	w, err := h.svc.Get(ctx, sess.Domain(0), request.GetId())
+	if err != nil {
+		return nil, h.webhookError("failed to read webhook", err)
+	}
+
+	return toWebhook(w), nil
+}
+
+func (h *WebhookHandler) UpdateWebhook(ctx context.Context, request *wmb.UpdateWebhookRequest) (*wmb.Webhook, error) {
+	sess, err := webhookSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = validateURL(request.GetUrl()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, NewBadRequest("valid.webhook.url", err).Error())
+	}
+
+	w, err := h.svc.Update(ctx, &model.Webhook{
+		Id:       request.GetId(),
+		DomainId: sess.Domain(0),
+		Url:      request.GetUrl(),
+		Events:   request.GetEvents(),
+		Secret:   request.GetSecret(),
+	}, request.Enabled)
+	if err != nil {
+		return nil, h.webhookError("failed to update webhook", err)
+	}
+
+	return toWebhook(w), nil
+}
+
+func (h *WebhookHandler) DeleteWebhook(ctx context.Context, request *wmb.DeleteWebhookRequest) (*wmb.DeleteWebhookResponse, error) {
+	sess, err := webhookSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = h.svc.Delete(ctx, sess.Domain(0), request.GetId()); err != nil {
+		return nil, h.webhookError("failed to delete webhook", err)
+	}
+
+	return &wmb.DeleteWebhookResponse{}, nil
+}
+
+func (h *WebhookHandler) SearchWebhookDelivery(ctx context.Context, request *wmb.SearchWebhookDeliveryRequest) (*wmb.ListWebhookDelivery, error) {
+	sess, err := webhookSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	list, next, err := h.svc.ListDeliveries(ctx, sess.Domain(0), request.GetWebhookId(), int(request.GetPage()), int(request.GetSize()))
+	if err != nil {
+		return nil, h.webhookError("failed to search webhook deliveries", err)
+	}
+
+	res := &wmb.ListWebhookDelivery{
+		Page:  request.GetPage(),
+		Next:  next,
+		Items: make([]*wmb.WebhookDelivery, 0, len(list)),
+	}
+
+	for _, d := range list {
+		item := &wmb.WebhookDelivery{
+			Id:         d.Id,
+			EventId:    d.EventId,
+			Event:      d.Event,
+			Attempt:    d.Attempt,
+			StatusCode: d.StatusCode,
+			DurationMs: d.DurationMs,
+			Success:    d.Success,
+			CreatedAt:  d.CreatedAt,
+		}
+		if d.Error != nil {
+			item.Error = *d.Error
+		}
+		res.Items = append(res.Items, item)
+	}
+
+	return res, nil
+}
+
+func (h *WebhookHandler) webhookError(msg string, err error) error {
+	switch {
+	case errors.Is(err, service.ErrWebhookNotFound):
+		return status.Error(codes.NotFound, NewHttpError(http.StatusNotFound, "webhook.not_found", err.Error()).Error())
+	case errors.Is(err, service.ErrWebhookInvalid):
+		return status.Error(codes.InvalidArgument, NewBadRequest("valid.webhook", err).Error())
+	}
+
+	h.log.Error(msg, wlog.Err(err))
+
+	return err
+}
+
+// webhookSession returns the caller session allowed to manage domain webhooks.
+func webhookSession(ctx context.Context) (*auth.Session, error) {
+	sess, err := grpc_srv.SessionFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !sess.HasAction(auth.PermissionSystemSetting) {
+		return nil, status.Error(codes.PermissionDenied,
+			NewHttpError(http.StatusForbidden, "webhook.permission", "system_setting permission required").Error())
+	}
+
+	return sess, nil
+}
+
+func toWebhook(w *model.Webhook) *wmb.Webhook {
+	res := &wmb.Webhook{
+		Id:        w.Id,
+		Url:       w.Url,
+		Events:    w.Events,
+		Enabled:   w.Enabled,
+		Failures:  w.Failures,
+		CreatedAt: w.CreatedAt,
+		UpdatedAt: w.UpdatedAt,
+	}
+
+	if w.DisabledAt != nil {
+		res.DisabledAt = *w.DisabledAt
+	}
+
+	return res
+}
@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/webitel/wlog"
+
+	wmb "github.com/webitel/web-meeting-backend/gen/web-meeting-backend"
+	"github.com/webitel/web-meeting-backend/infra/auth"
+	"github.com/webitel/web-meeting-backend/infra/grpc_srv"
+	"github.com/webitel/web-meeting-backend/internal/model"
+)
+
+// updatingWebhookService keeps the webhook and applies the updates like the service.
+type updatingWebhookService struct {
+	WebhookService
+
+	webhook *model.Webhook
+}
+
+func (s *updatingWebhookService) Update(_ context.Context, w *model.Webhook, enabled *bool) (*model.Webhook, error) {
+	w.Enabled = s.webhook.Enabled
+	if enabled != nil {
+		w.Enabled = *enabled
+	}
+	s.webhook = w
+
+	return w, nil
+}
+
+func TestWebhookHandler_UpdateWebhook(t *testing.T) {
+	svc := &updatingWebhookService{webhook: &model.Webhook{Id: 1, DomainId: 1, Enabled: true}}
+	h := &WebhookHandler{
+		log: wlog.NewLogger(&wlog.LoggerConfiguration{EnableConsole: false}),
+		svc: svc,
+	}
+
+	ctx := context.WithValue(context.Background(), grpc_srv.RequestContextSessionKey{},
+		auth.NewSession(1, 10, auth.PermissionSystemSetting))
+
+	// an update without enabled keeps the webhook enabled
+	res, err := h.UpdateWebhook(ctx, &wmb.UpdateWebhookRequest{
+		Id:     1,
+		Url:    "https://example.com/hook",
+		Events: []string{model.EventMeetingCreated},
+	})
+	require.NoError(t, err)
+	assert.True(t, res.Enabled)
+	assert.Equal(t, "https://example.com/hook", svc.webhook.Url)
+
+	res, err = h.UpdateWebhook(ctx, &wmb.UpdateWebhookRequest{
+		Id:      1,
+		Url:     "https://example.com/hook",
+		Events:  []string{model.EventMeetingCreated},
+		Enabled: proto.Bool(false),
+	})
+	require.NoError(t, err)
+	assert.False(t, res.Enabled)
+
+	res, err = h.UpdateWebhook(ctx, &wmb.UpdateWebhookRequest{
+		Id:     1,
+		Url:    "https://example.com/other",
+		Events: []string{model.EventMeetingCreated},
+	})
+	require.NoError(t, err)
+	assert.False(t, res.Enabled)
+}
@@ -2,28 +2,145 @@ package model
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"time"
 )
 
 const (
 	MeetingSatisfactionVarName = "meeting_satisfaction"
+	// MeetingIdVarName is the call variable with the public meeting id, reported back in the call events.
+	MeetingIdVarName = "meeting_id"
 )
 
+// Meeting variables with the target of the call started by the backend, the first set one is used.
+const (
+	MeetingVarCallUserId      = "call_user_id"
+	MeetingVarCallQueueId     = "call_queue_id"
+	MeetingVarCallDestination = "call_destination"
+)
+
+const (
+	CallTargetUser        = "user"
+	CallTargetQueue       = "queue"
+	CallTargetDestination = "destination"
+)
+
+// CallTarget is the user, queue or number the meeting call is originated to.
+type CallTarget struct {
+	Type        string
+	Id          int64
+	Destination string
+}
+
+// Call events published by the engine to the call exchange.
+const (
+	CallEventRinging = "ringing"
+	CallEventActive  = "active" // answered, or resumed after hold
+	CallEventBridge  = "bridge"
+	CallEventHold    = "hold"
+	CallEventHangup  = "hangup"
+)
+
+// ErrCallEventClaimed is returned for the call event claimed by another delivery which is still
+// being processed; the event is retried once the claim is done or its lease is over.
+var ErrCallEventClaimed = errors.New("call event is being processed")
+
+var CallEvents = []string{
+	CallEventRinging,
+	CallEventActive,
+	CallEventBridge,
+	CallEventHold,
+	CallEventHangup,
+}
+
+type Lookup struct {
+	Id   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+// CallRecording is the recorded file of the call.
+type CallRecording struct {
+	Id       int64  `json:"id"`
+	Name     string `json:"name"`
+	MimeType string `json:"mime_type"`
+	Size     int64  `json:"size"`
+	StartAt  int64  `json:"start_at"`
+	StopAt   int64  `json:"stop_at"`
+}
+
+// CallDetails is the engine view of the meeting call; times are in Unix milliseconds.
+type CallDetails struct {
+	Id          string           `json:"id"`
+	ParentId    string           `json:"parent_id"`
+	Active      bool             `json:"active"`
+	Direction   string           `json:"direction"`
+	Destination string           `json:"destination"`
+	User        *Lookup          `json:"user"`
+	Agent       *Lookup          `json:"agent"`
+	Queue       *Lookup          `json:"queue"`
+	CreatedAt   int64            `json:"created_at"`
+	AnsweredAt  int64            `json:"answered_at"`
+	BridgedAt   int64            `json:"bridged_at"`
+	HangupAt    int64            `json:"hangup_at"`
+	Duration    int32            `json:"duration"`
+	WaitSec     int32            `json:"wait_sec"`
+	HoldSec     int32            `json:"hold_sec"`
+	TalkSec     int32            `json:"talk_sec"`
+	Cause       string           `json:"cause"`
+	HangupBy    string           `json:"hangup_by"`
+	Recordings  []*CallRecording `json:"recordings"`
+}
+
 type CallHangupData struct {
 	Cause     *string `json:"cause"`
 	MeetingId *string `json:"meeting_id,omitempty"`
+	ParentId  *string `json:"parent_id,omitempty"`
 	TalkSec   int     `json:"talk_sec,string"`
 	IsParent  bool    `json:"is_parent"`
 }
 
 type Call struct {
-	Id    string `json:"id"`
-	AppId string `json:"app_id"`
+	Id        string `json:"id"`
+	AppId     string `json:"app_id"`
+	Event     string `json:"event"`
+	Timestamp int64  `json:"timestamp"`
 
 	Data    CallHangupData  `json:"-"`
 	RawData json.RawMessage `json:"data"`
 }
 
+// At returns the event time in Unix seconds; the engine reports milliseconds.
+func (e *Call) At() int64 {
+	if e.Timestamp == 0 {
+		return time.Now().Unix()
+	}
+
+	if e.Timestamp > 1e12 {
+		return e.Timestamp / 1000
+	}
+
+	return e.Timestamp
+}
+
+// AtMilli returns the event time in Unix milliseconds, used to order events of the same call.
+func (e *Call) AtMilli() int64 {
+	if e.Timestamp == 0 {
+		return time.Now().UnixMilli()
+	}
+
+	if e.Timestamp > 1e12 {
+		return e.Timestamp
+	}
+
+	return e.Timestamp * 1000
+}
+
+// IsLeg reports whether the call is a child leg, e.g. the agent leg bridged to the customer.
+func (e *Call) IsLeg() bool {
+	return e.Data.ParentId != nil && *e.Data.ParentId != ""
+}
+
 func (e *Call) UnmarshalJSON(data []byte) error {
 	type Alias Call
 	aux := &struct {
@@ -36,6 +153,10 @@ func (e *Call) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
+	if len(e.RawData) == 0 {
+		return nil
+	}
+
 	var dataStr string
 	if err := json.Unmarshal(e.RawData, &dataStr); err == nil {
 		if err := json.Unmarshal([]byte(dataStr), &e.Data); err != nil {
@@ -1,7 +1,38 @@
 package model
 
+// MeetingConversationProp is the conversation prop with the id of the meeting the chat belongs to.
+const MeetingConversationProp = "wbt_meeting_id"
+
+// MeetingChat is the chat conversation started for the meeting customer.
+type MeetingChat struct {
+	// Connection is the chat gateway the customer channel is connected via.
+	Connection string
+	// Username is the customer name shown to the agents.
+	Username string
+}
+
+// ChatMessage is the message of the meeting conversation.
+type ChatMessage struct {
+	Id        int64     `json:"id"`
+	ChannelId string    `json:"channel_id"`
+	Type      string    `json:"type"`
+	Text      string    `json:"text,omitempty"`
+	File      *ChatFile `json:"file,omitempty"`
+	// Customer is set for the messages of the meeting customer.
+	Customer  bool  `json:"customer"`
+	CreatedAt int64 `json:"created_at"`
+}
+
+type ChatFile struct {
+	Id   int64  `json:"id"`
+	Url  string `json:"url"`
+	Mime string `json:"mime"`
+	Name string `json:"name"`
+	Size int64  `json:"size"`
+}
+
 type ChatCloseInfo struct {
-	ConversationId string `json:"conversation_id" db:"conversation_id"`
-	CloserId       string `json:"closer_id" db:"closer_id"`
-	AuthUserId     int64  `json:"auth_user_id" db:"auth_user_id"`
+	ConversationId string `json:"conversation_id"`
+	CloserId       string `json:"closer_id"`
+	AuthUserId     int64  `json:"auth_user_id"`
 }
@@ -0,0 +1,90 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// ChatCloseMode decides what happens to the meeting chat once the agent call ends.
+type ChatCloseMode string
+
+const (
+	ChatCloseBridged  ChatCloseMode = "bridged"  // close only after the customer talked with an agent
+	ChatCloseAlways   ChatCloseMode = "always"   // close after any agent call
+	ChatCloseNever    ChatCloseMode = "never"    // keep the chat open
+	ChatCloseTransfer ChatCloseMode = "transfer" // transfer the chat back to the queue schema
+)
+
+var ChatCloseModes = []ChatCloseMode{
+	ChatCloseBridged,
+	ChatCloseAlways,
+	ChatCloseNever,
+	ChatCloseTransfer,
+}
+
+// Close causes of the chat server conversation.
+var ChatCloseCauses = []string{
+	"no_cause",
+	"flow_end",
+	"client_leave",
+	"flow_err",
+	"broadcast_end",
+}
+
+// maxChatCloseDelaySec limits the time the customer can still leave a message.
+const maxChatCloseDelaySec = 24 * 60 * 60
+
+// ChatPolicy is the domain policy of the meeting chat once the agent call ends.
+type ChatPolicy struct {
+	DomainId int64         `json:"domain_id" db:"domain_id"`
+	Mode     ChatCloseMode `json:"mode" db:"mode"`
+	// Cause is the close cause of the conversation.
+	Cause string `json:"cause" db:"cause"`
+	// DelaySec postpones the close or the transfer, so the customer can still leave a message.
+	DelaySec int `json:"delay_sec" db:"delay_sec"`
+	// SchemaId is the flow schema the chat is transferred to.
+	SchemaId  int64 `json:"schema_id" db:"schema_id"`
+	UpdatedAt int64 `json:"updated_at" db:"updated_at"`
+}
+
+// DefaultChatPolicy closes the chat right after any agent call.
+func DefaultChatPolicy(domainId int64) *ChatPolicy {
+	return &ChatPolicy{
+		DomainId: domainId,
+		Mode:     ChatCloseAlways,
+		Cause:    ChatCloseCauses[0],
+	}
+}
+
+func (p *ChatPolicy) Validate() error {
+	if !slices.Contains(ChatCloseModes, p.Mode) {
+		return fmt.Errorf("invalid chat close mode %q", p.Mode)
+	}
+
+	if !slices.Contains(ChatCloseCauses, p.Cause) {
+		return fmt.Errorf("invalid chat close cause %q", p.Cause)
+	}
+
+	if p.DelaySec < 0 || p.DelaySec > maxChatCloseDelaySec {
+		return fmt.Errorf("chat close delay must be 0-%d seconds", maxChatCloseDelaySec)
+	}
+
+	if p.Mode == ChatCloseTransfer && p.SchemaId <= 0 {
+		return errors.New("schema_id is required to transfer the chat")
+	}
+
+	return nil
+}
+
+// Applies reports whether the chat is closed or transferred after the agent call.
+func (p *ChatPolicy) Applies(bridged bool) bool {
+	switch p.Mode {
+	case ChatCloseAlways, ChatCloseTransfer:
+		return true
+	case ChatCloseBridged:
+		return bridged
+	}
+
+	return false
+}
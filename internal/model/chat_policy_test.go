@@ -0,0 +1,42 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChatPolicy_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  ChatPolicy
+		wantErr bool
+	}{
+		{name: "default", policy: *DefaultChatPolicy(1)},
+		{name: "delayed transfer", policy: ChatPolicy{Mode: ChatCloseTransfer, Cause: "flow_end", DelaySec: 60, SchemaId: 5}},
+		{name: "unknown mode", policy: ChatPolicy{Mode: "later", Cause: "no_cause"}, wantErr: true},
+		{name: "unknown cause", policy: ChatPolicy{Mode: ChatCloseNever, Cause: "timeout"}, wantErr: true},
+		{name: "negative delay", policy: ChatPolicy{Mode: ChatCloseAlways, Cause: "no_cause", DelaySec: -1}, wantErr: true},
+		{name: "transfer without schema", policy: ChatPolicy{Mode: ChatCloseTransfer, Cause: "no_cause"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestChatPolicy_Applies(t *testing.T) {
+	assert.True(t, (&ChatPolicy{Mode: ChatCloseAlways}).Applies(false))
+	assert.True(t, (&ChatPolicy{Mode: ChatCloseTransfer}).Applies(false))
+	assert.True(t, (&ChatPolicy{Mode: ChatCloseBridged}).Applies(true))
+	assert.False(t, (&ChatPolicy{Mode: ChatCloseBridged}).Applies(false))
+	assert.False(t, (&ChatPolicy{Mode: ChatCloseNever}).Applies(true))
+}
@@ -0,0 +1,42 @@
+package model
+
+import (
+	"strings"
+)
+
+// Meeting variables with the customer contact details.
+const (
+	MeetingVarContactName  = "name"
+	MeetingVarContactPhone = "phone"
+	MeetingVarContactEmail = "email"
+)
+
+// ContactDraft is the contact created from the meeting conversation.
+type ContactDraft struct {
+	// Name is the contact name, empty to take the one of the conversation client.
+	Name string
+	// Description keeps the phone and the email of the customer.
+	Description string
+}
+
+// ContactDraft returns the contact details from the meeting variables.
+func (meeting *Meeting) ContactDraft() *ContactDraft {
+	var lines []string
+
+	for _, v := range []struct {
+		label string
+		name  string
+	}{
+		{"Phone", MeetingVarContactPhone},
+		{"Email", MeetingVarContactEmail},
+	} {
+		if value := strings.TrimSpace(meeting.Variables[v.name]); value != "" {
+			lines = append(lines, v.label+": "+value)
+		}
+	}
+
+	return &ContactDraft{
+		Name:        strings.TrimSpace(meeting.Variables[MeetingVarContactName]),
+		Description: strings.Join(lines, "\n"),
+	}
+}
@@ -0,0 +1,22 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMeeting_ContactDraft(t *testing.T) {
+	meeting := &Meeting{Variables: map[string]string{
+		MeetingVarContactName:  " Olena ",
+		MeetingVarContactPhone: "+380501234567",
+		MeetingVarContactEmail: "olena@example.com",
+	}}
+
+	assert.Equal(t, &ContactDraft{
+		Name:        "Olena",
+		Description: "Phone: +380501234567\nEmail: olena@example.com",
+	}, meeting.ContactDraft())
+
+	assert.Equal(t, &ContactDraft{}, (&Meeting{}).ContactDraft())
+}
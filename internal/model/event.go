@@ -0,0 +1,38 @@
+package model
+
+const (
+	EventMeetingCreated      = "meeting.created"
+	EventMeetingDeleted      = "meeting.deleted"
+	EventMeetingCallEnded    = "meeting.call_ended"
+	EventMeetingEnded        = "meeting.ended"
+	EventMeetingSatisfaction = "meeting.satisfaction"
+)
+
+var MeetingEventTypes = []string{
+	EventMeetingCreated,
+	EventMeetingDeleted,
+	EventMeetingCallEnded,
+	EventMeetingEnded,
+	EventMeetingSatisfaction,
+}
+
+// MeetingEvent is a meeting lifecycle notification delivered to subscribers.
+// MeetingId is the public meeting token, the same one returned by CreateMeeting.
+type MeetingEvent struct {
+	Id        string         `json:"id"`
+	Type      string         `json:"event"`
+	DomainId  int64          `json:"domain_id"`
+	MeetingId string         `json:"meeting_id"`
+	CreatedAt int64          `json:"created_at"`
+	Data      map[string]any `json:"data,omitempty"`
+}
+
+func IsMeetingEventType(name string) bool {
+	for _, v := range MeetingEventTypes {
+		if v == name {
+			return true
+		}
+	}
+
+	return false
+}
@@ -1,5 +1,21 @@
 package model
 
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type MeetingState string
+
+const (
+	MeetingStateCreated         MeetingState = "created"
+	MeetingStateCustomerWaiting MeetingState = "customer_waiting"
+	MeetingStateAgentConnected  MeetingState = "agent_connected"
+	MeetingStateOnHold          MeetingState = "on_hold"
+	MeetingStateEnded           MeetingState = "ended"
+)
+
 type Meeting struct {
 	Id           string            `json:"id" db:"id"`
 	DomainId     int64             `json:"domain_id" db:"domain_id"`
@@ -11,8 +27,135 @@ type Meeting struct {
 	CallId       *string           `json:"call_id" db:"call_id"`
 	Satisfaction *string           `json:"satisfaction" db:"satisfaction"`
 	Bridged      bool              `json:"bridged" db:"bridged"`
+	State        MeetingState      `json:"state" db:"state"`
+	StateAt      *int64            `json:"state_at" db:"state_at"`
+	RingingAt    *int64            `json:"ringing_at" db:"ringing_at"`
+	AnsweredAt   *int64            `json:"answered_at" db:"answered_at"`
+	BridgedAt    *int64            `json:"bridged_at" db:"bridged_at"`
+	EndedAt      *int64            `json:"ended_at" db:"ended_at"`
+	Outcome      *MeetingOutcome   `json:"outcome" db:"outcome"`
+	// ConversationId is the chat conversation linked to the meeting.
+	ConversationId *string `json:"conversation_id" db:"conversation_id"`
+	// ChatChannelId is the customer channel of the conversation started by the meeting.
+	ChatChannelId *string `json:"chat_channel_id" db:"chat_channel_id"`
+	// ContactId is the contact linked to the meeting conversation.
+	ContactId *string        `json:"contact_id" db:"contact_id"`
+	Calls     []*MeetingCall `json:"calls" db:"-"`
+}
+
+// MeetingDetails is the meeting with the engine details of its calls.
+type MeetingDetails struct {
+	Meeting *Meeting
+	Calls   []*CallDetails
+}
+
+// MeetingCall is the call leg of the meeting: the customer call, or an agent leg with the parent id.
+type MeetingCall struct {
+	CallId    string  `json:"call_id" db:"call_id"`
+	ParentId  *string `json:"parent_id" db:"parent_id"`
+	Cause     *string `json:"cause" db:"cause"`
+	TalkSec   int     `json:"talk_sec" db:"talk_sec"`
+	StartedAt int64   `json:"started_at" db:"started_at"`
+	EndedAt   *int64  `json:"ended_at" db:"ended_at"`
+}
+
+// IsLeg reports whether the call is an agent leg of the customer call.
+func (c *MeetingCall) IsLeg() bool {
+	return c.ParentId != nil && *c.ParentId != ""
 }
 
 func (meeting *Meeting) AllowSatisfaction() bool {
 	return meeting.Bridged && meeting.CallId != nil && meeting.Satisfaction == nil
 }
+
+// CallTarget returns the call target from the meeting variables, nil when none is set.
+func (meeting *Meeting) CallTarget() (*CallTarget, error) {
+	for _, t := range []struct {
+		name string
+		kind string
+	}{
+		{MeetingVarCallUserId, CallTargetUser},
+		{MeetingVarCallQueueId, CallTargetQueue},
+	} {
+		v := strings.TrimSpace(meeting.Variables[t.name])
+		if v == "" {
+			continue
+		}
+
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || id <= 0 {
+			return nil, fmt.Errorf("invalid %s variable %q", t.name, v)
+		}
+
+		return &CallTarget{Type: t.kind, Id: id}, nil
+	}
+
+	if v := strings.TrimSpace(meeting.Variables[MeetingVarCallDestination]); v != "" {
+		return &CallTarget{Type: CallTargetDestination, Destination: v}, nil
+	}
+
+	return nil, nil
+}
+
+// AnswerSec returns the time the customer waited for an agent, or 0 when unanswered.
+func (meeting *Meeting) AnswerSec() int64 {
+	if meeting.RingingAt == nil || meeting.AnsweredAt == nil || *meeting.AnsweredAt < *meeting.RingingAt {
+		return 0
+	}
+
+	return *meeting.AnsweredAt - *meeting.RingingAt
+}
+
+// HasChat reports whether the customer can exchange text messages in the meeting conversation.
+func (meeting *Meeting) HasChat() bool {
+	return meeting.ConversationId != nil && meeting.ChatChannelId != nil
+}
+
+// CustomerChannel returns the customer channel of the meeting conversation, empty when the chat was not started by the meeting.
+func (meeting *Meeting) CustomerChannel() string {
+	if meeting.ChatChannelId == nil {
+		return ""
+	}
+
+	return *meeting.ChatChannelId
+}
+
+// CurrentCall returns the latest active agent leg, or the active customer call when no agent leg is active.
+func (meeting *Meeting) CurrentCall() *MeetingCall {
+	var current *MeetingCall
+	for _, c := range meeting.Calls {
+		if c.EndedAt != nil {
+			continue
+		}
+
+		if current == nil || c.IsLeg() || !current.IsLeg() {
+			current = c
+		}
+	}
+
+	return current
+}
+
+// TalkSec returns the total time agents talked with the customer.
+func (meeting *Meeting) TalkSec() int64 {
+	var sec int64
+	for _, c := range meeting.Calls {
+		if c.IsLeg() {
+			sec += int64(c.TalkSec)
+		}
+	}
+
+	return sec
+}
+
+// Attempts returns the number of agent legs offered the customer call.
+func (meeting *Meeting) Attempts() int {
+	var n int
+	for _, c := range meeting.Calls {
+		if c.IsLeg() {
+			n++
+		}
+	}
+
+	return n
+}
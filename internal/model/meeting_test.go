@@ -0,0 +1,68 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMeeting_TalkSecAttempts(t *testing.T) {
+	parent := "call"
+
+	meeting := Meeting{Calls: []*MeetingCall{
+		{CallId: parent, TalkSec: 90},
+		{CallId: "leg1", ParentId: &parent},
+		{CallId: "leg2", ParentId: &parent, TalkSec: 40},
+	}}
+
+	assert.Equal(t, int64(40), meeting.TalkSec())
+	assert.Equal(t, 2, meeting.Attempts())
+}
+
+func TestMeeting_CallTarget(t *testing.T) {
+	tests := []struct {
+		name    string
+		vars    map[string]string
+		target  *CallTarget
+		wantErr bool
+	}{
+		{name: "none"},
+		{name: "user", vars: map[string]string{MeetingVarCallUserId: "10", MeetingVarCallDestination: "100"}, target: &CallTarget{Type: CallTargetUser, Id: 10}},
+		{name: "queue", vars: map[string]string{MeetingVarCallQueueId: " 5 "}, target: &CallTarget{Type: CallTargetQueue, Id: 5}},
+		{name: "destination", vars: map[string]string{MeetingVarCallDestination: "+380441234567"}, target: &CallTarget{Type: CallTargetDestination, Destination: "+380441234567"}},
+		{name: "invalid user", vars: map[string]string{MeetingVarCallUserId: "agent"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			meeting := Meeting{Variables: tt.vars}
+
+			target, err := meeting.CallTarget()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.target, target)
+		})
+	}
+}
+
+func TestMeeting_CurrentCall(t *testing.T) {
+	parent := "call"
+	ended := int64(100)
+
+	meeting := Meeting{Calls: []*MeetingCall{
+		{CallId: parent},
+		{CallId: "leg1", ParentId: &parent, EndedAt: &ended},
+		{CallId: "leg2", ParentId: &parent},
+	}}
+	assert.Equal(t, "leg2", meeting.CurrentCall().CallId)
+
+	meeting.Calls[2].EndedAt = &ended
+	assert.Equal(t, parent, meeting.CurrentCall().CallId)
+
+	meeting.Calls[0].EndedAt = &ended
+	assert.Nil(t, meeting.CurrentCall())
+}
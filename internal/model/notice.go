@@ -0,0 +1,171 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ChatNotice is the meeting event posted into the linked chat as a service message.
+type ChatNotice string
+
+const (
+	ChatNoticeLinkSent      ChatNotice = "link_sent"
+	ChatNoticeLinkOpened    ChatNotice = "link_opened"
+	ChatNoticeCallConnected ChatNotice = "call_connected"
+	ChatNoticeCallEnded     ChatNotice = "call_ended"
+	ChatNoticeSatisfaction  ChatNotice = "satisfaction"
+)
+
+var ChatNoticeList = []ChatNotice{
+	ChatNoticeLinkSent,
+	ChatNoticeLinkOpened,
+	ChatNoticeCallConnected,
+	ChatNoticeCallEnded,
+	ChatNoticeSatisfaction,
+}
+
+// MeetingVarLanguage is the meeting variable with the language of the chat notices, e.g. "uk".
+const MeetingVarLanguage = "language"
+
+const defaultNoticeLanguage = "en"
+
+// DefaultChatNoticeTemplates are the notice texts by language. The {title}, {url}, {duration}
+// and {satisfaction} placeholders are replaced with the meeting values.
+var DefaultChatNoticeTemplates = map[string]map[ChatNotice]string{
+	"en": {
+		ChatNoticeLinkSent:      "Video meeting link sent: {url}",
+		ChatNoticeLinkOpened:    "The customer opened the video meeting link",
+		ChatNoticeCallConnected: "Video call connected",
+		ChatNoticeCallEnded:     "Video call ended, duration {duration}",
+		ChatNoticeSatisfaction:  "The customer rated the video meeting: {satisfaction}",
+	},
+	"uk": {
+		ChatNoticeLinkSent:      "Надіслано посилання на відеозустріч: {url}",
+		ChatNoticeLinkOpened:    "Клієнт відкрив посилання на відеозустріч",
+		ChatNoticeCallConnected: "Відеодзвінок з'єднано",
+		ChatNoticeCallEnded:     "Відеодзвінок завершено, тривалість {duration}",
+		ChatNoticeSatisfaction:  "Клієнт оцінив відеозустріч: {satisfaction}",
+	},
+}
+
+// ChatNotices renders the enabled notices in the meeting language.
+type ChatNotices struct {
+	enabled   map[ChatNotice]bool
+	language  string
+	templates map[string]map[ChatNotice]string
+}
+
+// NewChatNotices enables the notices listed with commas, "*" for all of them, in the default language.
+// The templates override DefaultChatNoticeTemplates per language and notice.
+func NewChatNotices(enabled, language string, templates map[string]map[ChatNotice]string) (*ChatNotices, error) {
+	n := &ChatNotices{
+		enabled:   make(map[ChatNotice]bool),
+		language:  strings.ToLower(strings.TrimSpace(language)),
+		templates: make(map[string]map[ChatNotice]string),
+	}
+
+	if n.language == "" {
+		n.language = defaultNoticeLanguage
+	}
+
+	for _, name := range strings.Split(enabled, ",") {
+		name = strings.TrimSpace(name)
+		switch {
+		case name == "":
+		case name == "*":
+			for _, notice := range ChatNoticeList {
+				n.enabled[notice] = true
+			}
+		case isChatNotice(ChatNotice(name)):
+			n.enabled[ChatNotice(name)] = true
+		default:
+			return nil, fmt.Errorf("invalid chat notice %q", name)
+		}
+	}
+
+	for _, src := range []map[string]map[ChatNotice]string{DefaultChatNoticeTemplates, templates} {
+		for lang, texts := range src {
+			lang = strings.ToLower(lang)
+			if n.templates[lang] == nil {
+				n.templates[lang] = make(map[ChatNotice]string)
+			}
+
+			for notice, text := range texts {
+				n.templates[lang][notice] = text
+			}
+		}
+	}
+
+	return n, nil
+}
+
+// ParseChatNoticeTemplates parses the JSON object of the notice texts by language and notice.
+func ParseChatNoticeTemplates(data []byte) (map[string]map[ChatNotice]string, error) {
+	var res map[string]map[ChatNotice]string
+	if err := json.Unmarshal(data, &res); err != nil {
+		return nil, fmt.Errorf("invalid chat notice templates: %w", err)
+	}
+
+	for lang, texts := range res {
+		for notice := range texts {
+			if !isChatNotice(notice) {
+				return nil, fmt.Errorf("invalid chat notice %q of language %q", notice, lang)
+			}
+		}
+	}
+
+	return res, nil
+}
+
+// Enabled reports whether the notice is posted.
+func (n *ChatNotices) Enabled(notice ChatNotice) bool {
+	return n.enabled[notice]
+}
+
+// Text returns the notice in the language, falling back to its base language, the default one and English.
+// Returns false when the notice is disabled.
+func (n *ChatNotices) Text(notice ChatNotice, language string, args map[string]string) (string, bool) {
+	if !n.Enabled(notice) {
+		return "", false
+	}
+
+	language = strings.ToLower(strings.TrimSpace(language))
+	base, _, _ := strings.Cut(language, "-")
+
+	for _, lang := range []string{language, base, n.language, defaultNoticeLanguage} {
+		text, ok := n.templates[lang][notice]
+		if !ok {
+			continue
+		}
+
+		pairs := make([]string, 0, len(args)*2)
+		for k, v := range args {
+			pairs = append(pairs, "{"+k+"}", v)
+		}
+
+		return strings.NewReplacer(pairs...).Replace(text), true
+	}
+
+	return "", false
+}
+
+// FormatDuration formats the seconds as m:ss, or h:mm:ss from an hour.
+func FormatDuration(sec int) string {
+	sec = max(sec, 0)
+	if sec >= 3600 {
+		return fmt.Sprintf("%d:%02d:%02d", sec/3600, sec%3600/60, sec%60)
+	}
+
+	return fmt.Sprintf("%d:%02d", sec/60, sec%60)
+}
+
+func isChatNotice(n ChatNotice) bool {
+	for _, v := range ChatNoticeList {
+		if v == n {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,52 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChatNotices_Text(t *testing.T) {
+	notices, err := NewChatNotices("call_ended, satisfaction", "uk", map[string]map[ChatNotice]string{
+		"pl": {ChatNoticeCallEnded: "Rozmowa zakończona, {duration}"},
+	})
+	require.NoError(t, err)
+
+	args := map[string]string{"duration": "1:05"}
+
+	tests := []struct {
+		name     string
+		notice   ChatNotice
+		language string
+		want     string
+		ok       bool
+	}{
+		{name: "disabled", notice: ChatNoticeLinkSent, language: "en"},
+		{name: "language", notice: ChatNoticeCallEnded, language: "en", want: "Video call ended, duration 1:05", ok: true},
+		{name: "base language", notice: ChatNoticeCallEnded, language: "pl-PL", want: "Rozmowa zakończona, 1:05", ok: true},
+		{name: "default language", notice: ChatNoticeCallEnded, language: "de", want: "Відеодзвінок завершено, тривалість 1:05", ok: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text, ok := notices.Text(tt.notice, tt.language, args)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, text)
+		})
+	}
+}
+
+func TestNewChatNotices_Invalid(t *testing.T) {
+	_, err := NewChatNotices("call_ended,unknown", "", nil)
+	assert.Error(t, err)
+
+	_, err = ParseChatNoticeTemplates([]byte(`{"en": {"unknown": "text"}}`))
+	assert.Error(t, err)
+}
+
+func TestFormatDuration(t *testing.T) {
+	assert.Equal(t, "0:00", FormatDuration(-1))
+	assert.Equal(t, "1:05", FormatDuration(65))
+	assert.Equal(t, "1:01:01", FormatDuration(3661))
+}
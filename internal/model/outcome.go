@@ -0,0 +1,150 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+type MeetingOutcome string
+
+const (
+	MeetingOutcomeInProgress        MeetingOutcome = "in_progress"
+	MeetingOutcomeCompleted         MeetingOutcome = "completed"          // an agent talked with the customer
+	MeetingOutcomeCustomerAbandoned MeetingOutcome = "customer_abandoned" // the customer left before talking with an agent
+	MeetingOutcomeAgentMissed       MeetingOutcome = "agent_missed"       // agents were offered the call, none answered
+	MeetingOutcomeTechnicalFailure  MeetingOutcome = "technical_failure"
+)
+
+var MeetingOutcomes = []MeetingOutcome{
+	MeetingOutcomeCompleted,
+	MeetingOutcomeCustomerAbandoned,
+	MeetingOutcomeAgentMissed,
+	MeetingOutcomeTechnicalFailure,
+}
+
+// DefaultCauseOutcomes classifies the engine hangup causes of the customer call.
+var DefaultCauseOutcomes = map[string]MeetingOutcome{
+	"NORMAL_CLEARING":           MeetingOutcomeCompleted,
+	"ORIGINATOR_CANCEL":         MeetingOutcomeCustomerAbandoned,
+	"LOSE_RACE":                 MeetingOutcomeCustomerAbandoned,
+	"NO_ANSWER":                 MeetingOutcomeAgentMissed,
+	"NO_USER_RESPONSE":          MeetingOutcomeAgentMissed,
+	"USER_BUSY":                 MeetingOutcomeAgentMissed,
+	"CALL_REJECTED":             MeetingOutcomeAgentMissed,
+	"ALLOTTED_TIMEOUT":          MeetingOutcomeAgentMissed,
+	"DESTINATION_OUT_OF_ORDER":  MeetingOutcomeTechnicalFailure,
+	"NETWORK_OUT_OF_ORDER":      MeetingOutcomeTechnicalFailure,
+	"NORMAL_TEMPORARY_FAILURE":  MeetingOutcomeTechnicalFailure,
+	"INCOMPATIBLE_DESTINATION":  MeetingOutcomeTechnicalFailure,
+	"MEDIA_TIMEOUT":             MeetingOutcomeTechnicalFailure,
+	"RECOVERY_ON_TIMER_EXPIRE":  MeetingOutcomeTechnicalFailure,
+	"SERVICE_UNAVAILABLE":       MeetingOutcomeTechnicalFailure,
+	"BEARERCAPABILITY_NOTAVAIL": MeetingOutcomeTechnicalFailure,
+}
+
+// OutcomeClassifier resolves the meeting outcome from its call legs and their hangup causes.
+type OutcomeClassifier struct {
+	causes map[string]MeetingOutcome
+}
+
+// NewOutcomeClassifier returns the classifier of DefaultCauseOutcomes with the overrides applied.
+func NewOutcomeClassifier(overrides map[string]MeetingOutcome) *OutcomeClassifier {
+	causes := make(map[string]MeetingOutcome, len(DefaultCauseOutcomes)+len(overrides))
+	for k, v := range DefaultCauseOutcomes {
+		causes[k] = v
+	}
+
+	for k, v := range overrides {
+		causes[normalizeCause(k)] = v
+	}
+
+	return &OutcomeClassifier{causes: causes}
+}
+
+// ParseCauseOutcomes parses the "CAUSE=outcome" pairs separated by commas. The completed outcome
+// requires an agent talk, so it can't be set by a cause.
+func ParseCauseOutcomes(s string) (map[string]MeetingOutcome, error) {
+	res := make(map[string]MeetingOutcome)
+
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		cause, outcome, ok := strings.Cut(pair, "=")
+		if !ok || strings.TrimSpace(cause) == "" {
+			return nil, fmt.Errorf("invalid hangup cause outcome %q", pair)
+		}
+
+		o := MeetingOutcome(strings.TrimSpace(outcome))
+		if !IsMeetingOutcome(o) {
+			return nil, fmt.Errorf("unknown meeting outcome %q of the hangup cause %s", o, cause)
+		}
+
+		if o == MeetingOutcomeCompleted {
+			return nil, fmt.Errorf("meeting outcome %q of the hangup cause %s requires an agent talk", o, cause)
+		}
+
+		res[normalizeCause(cause)] = o
+	}
+
+	return res, nil
+}
+
+func IsMeetingOutcome(o MeetingOutcome) bool {
+	for _, v := range MeetingOutcomes {
+		if v == o {
+			return true
+		}
+	}
+
+	return false
+}
+
+func normalizeCause(cause string) string {
+	return strings.ToUpper(strings.TrimSpace(cause))
+}
+
+// Classify returns in_progress until the customer call ends, and empty when no call has been made.
+// A meeting where an agent talked is completed; otherwise the customer call cause decides,
+// falling back to agent_missed when agents were offered the call and customer_abandoned when not.
+func (oc *OutcomeClassifier) Classify(calls []*MeetingCall) MeetingOutcome {
+	var customer *MeetingCall
+	var attempts int
+	var talked bool
+
+	for _, c := range calls {
+		if !c.IsLeg() {
+			customer = c
+			continue
+		}
+
+		attempts++
+		if c.TalkSec > 0 {
+			talked = true
+		}
+	}
+
+	switch {
+	case customer == nil && attempts == 0:
+		return ""
+	case customer == nil || customer.EndedAt == nil:
+		return MeetingOutcomeInProgress
+	case talked:
+		return MeetingOutcomeCompleted
+	}
+
+	if customer.Cause != nil {
+		// completed requires an agent talk, the customer may be cleared normally from the queue
+		if o, ok := oc.causes[normalizeCause(*customer.Cause)]; ok && o != MeetingOutcomeCompleted {
+			return o
+		}
+	}
+
+	if attempts > 0 {
+		return MeetingOutcomeAgentMissed
+	}
+
+	return MeetingOutcomeCustomerAbandoned
+}
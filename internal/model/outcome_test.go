@@ -0,0 +1,68 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutcomeClassifier_Classify(t *testing.T) {
+	parent := "call"
+	ended := int64(100)
+
+	customer := func(cause string) *MeetingCall {
+		return &MeetingCall{CallId: parent, Cause: &cause, EndedAt: &ended}
+	}
+	leg := func(talkSec int) *MeetingCall {
+		cause := "NO_ANSWER"
+		return &MeetingCall{CallId: "leg", ParentId: &parent, Cause: &cause, TalkSec: talkSec, EndedAt: &ended}
+	}
+
+	oc := NewOutcomeClassifier(map[string]MeetingOutcome{"user_busy": MeetingOutcomeCustomerAbandoned})
+
+	tests := []struct {
+		name    string
+		calls   []*MeetingCall
+		outcome MeetingOutcome
+	}{
+		{name: "no calls"},
+		{name: "customer waiting", calls: []*MeetingCall{{CallId: parent}}, outcome: MeetingOutcomeInProgress},
+		{name: "talked", calls: []*MeetingCall{customer("NORMAL_CLEARING"), leg(0), leg(40)}, outcome: MeetingOutcomeCompleted},
+		{name: "agent missed", calls: []*MeetingCall{customer("NORMAL_CLEARING"), leg(0)}, outcome: MeetingOutcomeAgentMissed},
+		{name: "customer cancel", calls: []*MeetingCall{customer("ORIGINATOR_CANCEL"), leg(0)}, outcome: MeetingOutcomeCustomerAbandoned},
+		{name: "customer left the queue", calls: []*MeetingCall{customer("NORMAL_CLEARING")}, outcome: MeetingOutcomeCustomerAbandoned},
+		{name: "media timeout", calls: []*MeetingCall{customer("MEDIA_TIMEOUT")}, outcome: MeetingOutcomeTechnicalFailure},
+		{name: "override", calls: []*MeetingCall{customer("USER_BUSY"), leg(0)}, outcome: MeetingOutcomeCustomerAbandoned},
+		{name: "override of the lower case cause", calls: []*MeetingCall{customer("user_busy"), leg(0)}, outcome: MeetingOutcomeCustomerAbandoned},
+		{name: "talking", calls: []*MeetingCall{{CallId: parent}, leg(40)}, outcome: MeetingOutcomeInProgress},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.outcome, oc.Classify(tt.calls))
+		})
+	}
+}
+
+func TestParseCauseOutcomes(t *testing.T) {
+	res, err := ParseCauseOutcomes("USER_BUSY=customer_abandoned, MEDIA_TIMEOUT = agent_missed,")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]MeetingOutcome{
+		"USER_BUSY":     MeetingOutcomeCustomerAbandoned,
+		"MEDIA_TIMEOUT": MeetingOutcomeAgentMissed,
+	}, res)
+
+	_, err = ParseCauseOutcomes("USER_BUSY")
+	require.Error(t, err)
+
+	_, err = ParseCauseOutcomes("USER_BUSY=unknown")
+	require.Error(t, err)
+
+	_, err = ParseCauseOutcomes("USER_BUSY=completed")
+	require.Error(t, err)
+
+	res, err = ParseCauseOutcomes(" user_busy =agent_missed")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]MeetingOutcome{"USER_BUSY": MeetingOutcomeAgentMissed}, res)
+}
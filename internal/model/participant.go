@@ -0,0 +1,22 @@
+package model
+
+// Participant is the guest who joined the meeting.
+type Participant struct {
+	Id        string `json:"id" db:"id"`
+	MeetingId string `json:"meeting_id" db:"meeting_id"`
+	CreatedAt int64  `json:"created_at" db:"created_at"`
+	RevokedAt *int64 `json:"revoked_at" db:"revoked_at"`
+}
+
+// IsRevoked reports whether the participant credentials are no longer accepted.
+func (p *Participant) IsRevoked() bool {
+	return p.RevokedAt != nil
+}
+
+// GuestCredential is the short-lived credential of the participant.
+type GuestCredential struct {
+	ParticipantId string
+	Token         string
+	// ExpiresAt is the expiry in unix seconds.
+	ExpiresAt int64
+}
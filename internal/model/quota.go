@@ -0,0 +1,28 @@
+package model
+
+import (
+	"time"
+)
+
+// MeetingQuota limits the meetings of every domain; zero is no limit.
+type MeetingQuota struct {
+	// License is the product license the user needs to create meetings, empty when none is required.
+	License string `json:"license"`
+	// MaxActive limits the meetings which are neither ended nor expired.
+	MaxActive int `json:"max_active"`
+	// MaxDaily limits the meetings created per UTC day.
+	MaxDaily int `json:"max_daily"`
+}
+
+// MeetingUsage is the current usage of the domain meetings against the quota.
+type MeetingUsage struct {
+	DomainId int64        `json:"domain_id"`
+	Active   int          `json:"active"`
+	Today    int          `json:"today"`
+	Quota    MeetingQuota `json:"quota"`
+}
+
+// UsageDay returns the start of the UTC day of the time, in unix seconds.
+func UsageDay(t time.Time) int64 {
+	return t.UTC().Truncate(24 * time.Hour).Unix()
+}
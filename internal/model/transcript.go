@@ -0,0 +1,94 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	TranscriptFormatText = "text"
+	TranscriptFormatJson = "json"
+)
+
+// Transcript is the meeting with the messages of its chat, oldest first.
+type Transcript struct {
+	Meeting  *Meeting       `json:"meeting"`
+	Messages []*ChatMessage `json:"messages"`
+}
+
+// TranscriptExport is the rendered transcript file.
+type TranscriptExport struct {
+	ContentType string
+	FileName    string
+	Data        []byte
+}
+
+// Export renders the transcript in the format, text by default.
+func (t *Transcript) Export(format string) (*TranscriptExport, error) {
+	name := "meeting-" + t.Meeting.Id
+
+	switch format {
+	case "", TranscriptFormatText:
+		return &TranscriptExport{
+			ContentType: "text/plain; charset=utf-8",
+			FileName:    name + ".txt",
+			Data:        []byte(t.Text()),
+		}, nil
+	case TranscriptFormatJson:
+		data, err := json.Marshal(t)
+		if err != nil {
+			return nil, err
+		}
+
+		return &TranscriptExport{
+			ContentType: "application/json",
+			FileName:    name + ".json",
+			Data:        data,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("unknown transcript format %q", format)
+}
+
+// Text renders the meeting summary followed by a line per message.
+func (t *Transcript) Text() string {
+	var b strings.Builder
+	m := t.Meeting
+
+	fmt.Fprintf(&b, "Meeting: %s\n", m.Title)
+	fmt.Fprintf(&b, "Created: %s\n", formatUnix(m.CreatedAt))
+	if m.EndedAt != nil {
+		fmt.Fprintf(&b, "Ended: %s\n", formatUnix(*m.EndedAt))
+	}
+	if m.Outcome != nil {
+		fmt.Fprintf(&b, "Outcome: %s\n", *m.Outcome)
+	}
+	fmt.Fprintf(&b, "Talk time: %s\n", FormatDuration(int(m.TalkSec())))
+	if m.Satisfaction != nil {
+		fmt.Fprintf(&b, "Satisfaction: %s\n", *m.Satisfaction)
+	}
+
+	b.WriteString("\n")
+
+	for _, msg := range t.Messages {
+		sender := "Agent"
+		if msg.Customer {
+			sender = "Customer"
+		}
+
+		text := msg.Text
+		if msg.File != nil {
+			text = strings.TrimSpace(text + " [file: " + msg.File.Name + "]")
+		}
+
+		fmt.Fprintf(&b, "[%s] %s: %s\n", time.UnixMilli(msg.CreatedAt).UTC().Format(time.DateTime), sender, text)
+	}
+
+	return b.String()
+}
+
+func formatUnix(sec int64) string {
+	return time.Unix(sec, 0).UTC().Format(time.DateTime)
+}
@@ -0,0 +1,34 @@
+package model
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTranscript_Export(t *testing.T) {
+	transcript := &Transcript{
+		Meeting: &Meeting{Id: "meeting", Title: "Support", CreatedAt: 1700000000},
+		Messages: []*ChatMessage{
+			{Id: 1, Text: "Camera is broken", Customer: true, CreatedAt: 1700000060000},
+			{Id: 2, Type: "file", File: &ChatFile{Name: "guide.pdf"}, CreatedAt: 1700000120000},
+		},
+	}
+
+	res, err := transcript.Export("")
+	require.NoError(t, err)
+	assert.Equal(t, "meeting-meeting.txt", res.FileName)
+	assert.Equal(t, "Meeting: Support\nCreated: 2023-11-14 22:13:20\nTalk time: 0:00\n\n"+
+		"[2023-11-14 22:14:20] Customer: Camera is broken\n"+
+		"[2023-11-14 22:15:20] Agent: [file: guide.pdf]\n", string(res.Data))
+
+	res, err = transcript.Export(TranscriptFormatJson)
+	require.NoError(t, err)
+	assert.Equal(t, "application/json", res.ContentType)
+	assert.True(t, json.Valid(res.Data))
+
+	_, err = transcript.Export("pdf")
+	assert.Error(t, err)
+}
@@ -0,0 +1,50 @@
+package model
+
+import "strings"
+
+// CallVarPrefix prefixes the meeting variables pushed into the meeting calls.
+const CallVarPrefix = "wbt_meeting_"
+
+// CallVariables selects the meeting variables pushed into the linked calls,
+// so the agent call card and the CDR show the meeting context.
+type CallVariables struct {
+	all   bool
+	names map[string]struct{}
+}
+
+// NewCallVariables parses the variable names separated by commas; "*" selects all of them,
+// the empty list selects none.
+func NewCallVariables(list string) *CallVariables {
+	cv := &CallVariables{names: make(map[string]struct{})}
+
+	for _, name := range strings.Split(list, ",") {
+		name = strings.TrimSpace(name)
+		switch name {
+		case "":
+		case "*":
+			cv.all = true
+		default:
+			cv.names[name] = struct{}{}
+		}
+	}
+
+	return cv
+}
+
+// Of returns the selected variables with CallVarPrefix, nil when none is selected.
+func (cv *CallVariables) Of(vars map[string]string) map[string]string {
+	var res map[string]string
+
+	for k, v := range vars {
+		if _, ok := cv.names[k]; !ok && !cv.all {
+			continue
+		}
+
+		if res == nil {
+			res = make(map[string]string)
+		}
+		res[CallVarPrefix+k] = v
+	}
+
+	return res
+}
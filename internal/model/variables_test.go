@@ -0,0 +1,27 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCallVariables_Of(t *testing.T) {
+	vars := map[string]string{"order": "42", "phone": "+380441234567"}
+
+	tests := []struct {
+		name string
+		list string
+		want map[string]string
+	}{
+		{name: "none", list: ""},
+		{name: "all", list: "*", want: map[string]string{"wbt_meeting_order": "42", "wbt_meeting_phone": "+380441234567"}},
+		{name: "subset", list: " order, missing", want: map[string]string{"wbt_meeting_order": "42"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, NewCallVariables(tt.list).Of(vars))
+		})
+	}
+}
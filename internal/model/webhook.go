@@ -0,0 +1,37 @@
+package model
+
+type Webhook struct {
+	Id         int64    `json:"id" db:"id"`
+	DomainId   int64    `json:"domain_id" db:"domain_id"`
+	Url        string   `json:"url" db:"url"`
+	Events     []string `json:"events" db:"events"`
+	Secret     string   `json:"-" db:"secret"`
+	Enabled    bool     `json:"enabled" db:"enabled"`
+	Failures   int32    `json:"failures" db:"failures"`
+	CreatedAt  int64    `json:"created_at" db:"created_at"`
+	UpdatedAt  int64    `json:"updated_at" db:"updated_at"`
+	DisabledAt *int64   `json:"disabled_at" db:"disabled_at"`
+}
+
+func (w *Webhook) Subscribed(event string) bool {
+	for _, v := range w.Events {
+		if v == event {
+			return true
+		}
+	}
+
+	return false
+}
+
+type WebhookDelivery struct {
+	Id         int64   `json:"id" db:"id"`
+	WebhookId  int64   `json:"webhook_id" db:"webhook_id"`
+	EventId    string  `json:"event_id" db:"event_id"`
+	Event      string  `json:"event" db:"event"`
+	Attempt    int32   `json:"attempt" db:"attempt"`
+	StatusCode int32   `json:"status_code" db:"status_code"`
+	Error      *string `json:"error" db:"error"`
+	DurationMs int64   `json:"duration_ms" db:"duration_ms"`
+	Success    bool    `json:"success" db:"success"`
+	CreatedAt  int64   `json:"created_at" db:"created_at"`
+}
@@ -2,7 +2,10 @@ package service
 
 import (
 	"context"
+
+	gen "github.com/webitel/web-meeting-backend/gen/engine"
 	"github.com/webitel/web-meeting-backend/infra/engine"
+	"github.com/webitel/web-meeting-backend/internal/model"
 )
 
 type CallService struct {
@@ -18,3 +21,119 @@ func NewCallService(cli *engine.Client) *CallService {
 func (s *CallService) SetVariables(ctx context.Context, domainId int64, callId string, vars map[string]string) error {
 	return s.cli.SetVariables(ctx, domainId, callId, vars)
 }
+
+// StartCall originates the call to the target with the variables, returns the call id.
+func (s *CallService) StartCall(ctx context.Context, domainId int64, target *model.CallTarget, vars map[string]string) (string, error) {
+	if target.Type == model.CallTargetDestination {
+		return s.cli.CreateCall(ctx, domainId, nil, target.Destination, vars)
+	}
+
+	return s.cli.CreateCall(ctx, domainId, &engine.Endpoint{Type: target.Type, Id: target.Id}, "", vars)
+}
+
+// ActiveCalls returns the active child calls of the parent call.
+func (s *CallService) ActiveCalls(ctx context.Context, token string, domainId int64, parentId string) ([]string, error) {
+	return s.cli.SearchActiveCalls(ctx, token, domainId, parentId)
+}
+
+func (s *CallService) Hangup(ctx context.Context, token string, domainId int64, callId, cause string) error {
+	return s.cli.HangupCall(ctx, token, domainId, callId, cause)
+}
+
+func (s *CallService) Hold(ctx context.Context, token string, domainId int64, callId string) error {
+	return s.cli.HoldCall(ctx, token, domainId, callId)
+}
+
+func (s *CallService) UnHold(ctx context.Context, token string, domainId int64, callId string) error {
+	return s.cli.UnHoldCall(ctx, token, domainId, callId)
+}
+
+func (s *CallService) BlindTransfer(ctx context.Context, token string, domainId int64, callId, destination string) error {
+	return s.cli.BlindTransferCall(ctx, token, domainId, callId, destination)
+}
+
+func (s *CallService) Dtmf(ctx context.Context, token string, domainId int64, callId, digit string) error {
+	return s.cli.DtmfCall(ctx, token, domainId, callId, digit)
+}
+
+// History returns the details of the finished calls created since the time in Unix seconds.
+func (s *CallService) History(ctx context.Context, token string, domainId int64, ids []string, since int64) ([]*model.CallDetails, error) {
+	items, err := s.cli.SearchHistoryCalls(ctx, token, domainId, ids, since*1000)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*model.CallDetails, 0, len(items))
+	for _, c := range items {
+		d := &model.CallDetails{
+			Id:          c.GetId(),
+			ParentId:    c.GetParentId(),
+			Direction:   c.GetDirection(),
+			Destination: c.GetDestination(),
+			User:        lookup(c.GetUser()),
+			Agent:       lookup(c.GetAgent()),
+			Queue:       lookup(c.GetQueue()),
+			CreatedAt:   c.GetCreatedAt(),
+			AnsweredAt:  c.GetAnsweredAt(),
+			BridgedAt:   c.GetBridgedAt(),
+			HangupAt:    c.GetHangupAt(),
+			Duration:    c.GetDuration(),
+			WaitSec:     c.GetWaitSec(),
+			HoldSec:     c.GetHoldSec(),
+			TalkSec:     c.GetTalkSec(),
+			Cause:       c.GetCause(),
+			HangupBy:    c.GetHangupBy(),
+		}
+
+		for _, f := range c.GetFiles() {
+			d.Recordings = append(d.Recordings, &model.CallRecording{
+				Id:       f.GetId(),
+				Name:     f.GetName(),
+				MimeType: f.GetMimeType(),
+				Size:     f.GetSize(),
+				StartAt:  f.GetStartAt(),
+				StopAt:   f.GetStopAt(),
+			})
+		}
+
+		res = append(res, d)
+	}
+
+	return res, nil
+}
+
+// Active returns the details of the active call.
+func (s *CallService) Active(ctx context.Context, token string, domainId int64, callId string) (*model.CallDetails, error) {
+	c, err := s.cli.ReadCall(ctx, token, domainId, callId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.CallDetails{
+		Id:          c.GetId(),
+		ParentId:    c.GetParentId(),
+		Active:      true,
+		Direction:   c.GetDirection(),
+		Destination: c.GetDestination(),
+		User:        lookup(c.GetUser()),
+		Agent:       lookup(c.GetAgent()),
+		Queue:       lookup(c.GetQueue()),
+		CreatedAt:   c.GetCreatedAt(),
+		AnsweredAt:  c.GetAnsweredAt(),
+		BridgedAt:   c.GetBridgedAt(),
+		Duration:    c.GetDuration(),
+		WaitSec:     c.GetWaitSec(),
+		HoldSec:     c.GetHoldSec(),
+	}, nil
+}
+
+func lookup(l *gen.Lookup) *model.Lookup {
+	if l == nil || (l.GetId() == 0 && l.GetName() == "") {
+		return nil
+	}
+
+	return &model.Lookup{
+		Id:   l.GetId(),
+		Name: l.GetName(),
+	}
+}
@@ -2,8 +2,13 @@ package service
 
 import (
 	"context"
-	"github.com/webitel/web-meeting-backend/infra/chat"
+	"strconv"
+
 	"github.com/webitel/wlog"
+
+	gen "github.com/webitel/web-meeting-backend/gen/chat"
+	"github.com/webitel/web-meeting-backend/infra/chat"
+	"github.com/webitel/web-meeting-backend/internal/model"
 )
 
 type ChatService struct {
@@ -18,7 +23,126 @@ func NewChatService(log *wlog.Logger, api *chat.Client) *ChatService {
 	}
 }
 
-func (c *ChatService) CloseChat(ctx context.Context, conversationId, closerId string, authUserId int64) error {
+func (c *ChatService) CloseChat(ctx context.Context, conversationId, closerId string, authUserId int64, cause string) error {
+	return c.api.CloseChat(ctx, conversationId, closerId, authUserId, cause)
+}
+
+// TransferChat transfers the conversation back to the flow schema, e.g. the queue.
+func (c *ChatService) TransferChat(ctx context.Context, conversationId, channelId string, schemaId int64) error {
+	return c.api.TransferChat(ctx, conversationId, channelId, schemaId)
+}
+
+// CloseInfo returns the conversation with the channel that closes it, nil when the conversation
+// of the domain is not found or already closed.
+func (c *ChatService) CloseInfo(ctx context.Context, domainId int64, conversationId string) (*model.ChatCloseInfo, error) {
+	conv, err := c.api.GetConversation(ctx, conversationId)
+	if err != nil {
+		return nil, err
+	}
+
+	if conv == nil || conv.DomainId != domainId || conv.ClosedAt != 0 || len(conv.Members) == 0 {
+		return nil, nil
+	}
+
+	// the first member is the channel that started the conversation
+	closer := conv.Members[0]
+
+	return &model.ChatCloseInfo{
+		ConversationId: conv.Id,
+		CloserId:       closer.ChannelId,
+		AuthUserId:     closer.UserId,
+	}, nil
+}
+
+// CreateContact creates the contact from the conversation on behalf of the caller and links it to the customer.
+// Returns the contact id.
+func (c *ChatService) CreateContact(ctx context.Context, token, conversationId string, draft *model.ContactDraft) (string, error) {
+	id, err := c.api.CreateContactFromConversation(ctx, token, conversationId, draft.Name, draft.Description)
+	if err != nil {
+		return "", err
+	}
+
+	return strconv.FormatInt(id, 10), nil
+}
+
+// LinkContact links the existing contact to the conversation customer.
+func (c *ChatService) LinkContact(ctx context.Context, conversationId, contactId string) error {
+	return c.api.LinkContact(ctx, conversationId, contactId)
+}
+
+// StartConversation opens the conversation of the meeting customer with the meeting id in the props.
+// Returns the conversation id and the customer channel id.
+func (c *ChatService) StartConversation(ctx context.Context, domainId int64, meetingId string, ch *model.MeetingChat, vars map[string]string) (string, string, error) {
+	props := make(map[string]string, len(vars)+1)
+	for k, v := range vars {
+		props[k] = v
+	}
+	props[model.MeetingConversationProp] = meetingId
+
+	return c.api.StartConversation(ctx, domainId, ch.Connection, ch.Username, props)
+}
+
+// SendText sends the customer text message to the conversation.
+func (c *ChatService) SendText(ctx context.Context, conversationId, channelId, text string) (*model.ChatMessage, error) {
+	msg, err := c.api.SendText(ctx, conversationId, channelId, text)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &model.ChatMessage{
+		ChannelId: channelId,
+		Type:      chat.MessageTypeText,
+		Text:      text,
+		Customer:  true,
+	}
+	if msg != nil {
+		res.Id = msg.Id
+		res.CreatedAt = msg.CreatedAt
+	}
+
+	return res, nil
+}
+
+// SendNotice posts the service message into the conversation.
+func (c *ChatService) SendNotice(ctx context.Context, conversationId, text string) error {
+	return c.api.SendServiceMessage(ctx, conversationId, text)
+}
+
+// History returns the page of the conversation messages, newest first, and whether there are more.
+// The messages of the customer channel are marked.
+func (c *ChatService) History(ctx context.Context, conversationId, channelId string, page, size int32) ([]*model.ChatMessage, bool, error) {
+	items, next, err := c.api.GetHistory(ctx, conversationId, page, size)
+	if err != nil {
+		return nil, false, err
+	}
+
+	res := make([]*model.ChatMessage, 0, len(items))
+	for _, m := range items {
+		res = append(res, chatMessage(m, channelId))
+	}
+
+	return res, next, nil
+}
+
+func chatMessage(m *gen.HistoryMessage, channelId string) *model.ChatMessage {
+	res := &model.ChatMessage{
+		Id:        m.Id,
+		ChannelId: m.ChannelId,
+		Type:      m.Type,
+		Text:      m.Text,
+		Customer:  channelId != "" && m.ChannelId == channelId,
+		CreatedAt: m.CreatedAt,
+	}
+
+	if f := m.File; f != nil {
+		res.File = &model.ChatFile{
+			Id:   f.Id,
+			Url:  f.Url,
+			Mime: f.Mime,
+			Name: f.Name,
+			Size: f.Size,
+		}
+	}
 
-	return c.api.CloseChat(ctx, conversationId, closerId, authUserId)
+	return res
 }
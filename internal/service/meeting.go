@@ -5,6 +5,9 @@ import (
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	gonanoid "github.com/matoous/go-nanoid/v2"
@@ -16,14 +19,66 @@ import (
 	"github.com/webitel/web-meeting-backend/internal/model"
 )
 
+// ErrInvalidToken is returned for a meeting id that can't be decrypted; retrying never helps.
+var ErrInvalidToken = errors.New("invalid token")
+
+// ErrCallInvalid is returned for a call event which can't be applied; retrying never helps.
+var ErrCallInvalid = errors.New("invalid call event")
+
+const (
+	defaultHangupCause = "NORMAL_CLEARING"
+	dtmfDigits         = "0123456789*#ABCD"
+)
+
+var (
+	ErrMeetingNotFound = errors.New("meeting not found")
+	ErrMeetingClosed   = errors.New("meeting is closed")
+	ErrMeetingInvalid  = errors.New("invalid meeting")
+	ErrMeetingNoChat   = errors.New("meeting has no chat")
+	ErrMeetingQuota    = errors.New("meeting quota exceeded")
+	ErrMeetingLicense  = errors.New("meeting license required")
+	ErrGuestInvalid    = errors.New("guest credential is invalid")
+)
+
+const (
+	maxMessageLength = 4096
+	// maxTranscriptMessages limits the exported transcript.
+	maxTranscriptMessages = 5000
+	transcriptPageSize    = 100
+
+	chatCloseInterval = 5 * time.Second
+	chatCloseBatch    = 100
+)
+
 type MeetingStore interface {
-	Create(ctx context.Context, m *model.Meeting) error
+	Create(ctx context.Context, m *model.Meeting, q model.MeetingQuota) (bool, error)
 	Get(ctx context.Context, id string) (*model.Meeting, error)
 	Delete(ctx context.Context, id string) error
-	SetCall(ctx context.Context, id, callId string, bridged bool) error
+	SetCall(ctx context.Context, id, callId string, bridged bool, eventAt int64) (bool, error)
 	SetSatisfaction(ctx context.Context, id, satisfaction string) error
-
-	GetChatCloseInfo(ctx context.Context, id string) (*model.ChatCloseInfo, error)
+	LinkCall(ctx context.Context, id, callId string, parentId *string, at int64) error
+	EndCall(ctx context.Context, id string, c *model.MeetingCall) error
+	GetCalls(ctx context.Context, id string) ([]*model.MeetingCall, error)
+	SetOutcome(ctx context.Context, id string, outcome model.MeetingOutcome) error
+	FindByCall(ctx context.Context, callId string) (string, error)
+	SetState(ctx context.Context, id string, state model.MeetingState, eventAt int64) error
+	SetBridged(ctx context.Context, id string, eventAt int64) error
+	End(ctx context.Context, id string, at int64) error
+	SetOpened(ctx context.Context, id string, at int64) (bool, error)
+	SetContact(ctx context.Context, id, contactId string) error
+	SetConversation(ctx context.Context, id, conversationId string) error
+	CountActive(ctx context.Context, domainId int64, now int64) (int, error)
+	DailyUsage(ctx context.Context, domainId int64, day int64) (int, error)
+	CreateParticipant(ctx context.Context, p *model.Participant) error
+	GetParticipant(ctx context.Context, id string) (*model.Participant, error)
+	RevokeParticipant(ctx context.Context, meetingId, id string, at int64) (bool, error)
+	ScheduleChatClose(ctx context.Context, id string, at int64) error
+	TakeChatCloses(ctx context.Context, now int64, limit int) ([]string, error)
+	GetChatPolicy(ctx context.Context, domainId int64) (*model.ChatPolicy, error)
+	SetChatPolicy(ctx context.Context, p *model.ChatPolicy) error
+	ClaimCallEvent(ctx context.Context, id, callId, event string, eventAt int64) (bool, error)
+	CompleteCallEvent(ctx context.Context, callId, event string, eventAt int64) error
+	ReleaseCallEvent(ctx context.Context, callId, event string, eventAt int64) error
 }
 
 type MeetingService struct {
@@ -34,11 +89,38 @@ type MeetingService struct {
 	call      *CallService
 	encrypter *encrypter.DataEncrypter
 	auth      auth.Manager
+	webhook   *WebhookService
+	outcomes  *model.OutcomeClassifier
+	callVars  *model.CallVariables
+	notices   *model.ChatNotices
+	quota     *model.MeetingQuota
+	guests    *auth.GuestSigner
 }
 
 func NewMeetingService(ctx context.Context, cs *ChatService, call *CallService, log *wlog.Logger, st MeetingStore,
-	enc *encrypter.DataEncrypter, a auth.Manager,
+	enc *encrypter.DataEncrypter, a auth.Manager, wh *WebhookService, oc *model.OutcomeClassifier, cv *model.CallVariables,
+	cn *model.ChatNotices, mq *model.MeetingQuota, gs *auth.GuestSigner,
 ) *MeetingService {
+	if oc == nil {
+		oc = model.NewOutcomeClassifier(nil)
+	}
+
+	if cv == nil {
+		cv = model.NewCallVariables("")
+	}
+
+	if cn == nil {
+		cn, _ = model.NewChatNotices("*", "", nil)
+	}
+
+	if mq == nil {
+		mq = &model.MeetingQuota{}
+	}
+
+	if gs == nil {
+		gs = auth.NewGuestSigner("", auth.DefaultGuestTTL)
+	}
+
 	return &MeetingService{
 		ctx:       ctx,
 		log:       log,
@@ -47,26 +129,125 @@ func NewMeetingService(ctx context.Context, cs *ChatService, call *CallService,
 		chat:      cs,
 		call:      call,
 		auth:      a,
+		webhook:   wh,
+		outcomes:  oc,
+		callVars:  cv,
+		notices:   cn,
+		quota:     mq,
+		guests:    gs,
+	}
+}
+
+// CheckLicense returns ErrMeetingLicense when the user of the domain has no product license required
+// to create meetings. For the internal caller without the user session the license of the domain is checked.
+func (s *MeetingService) CheckLicense(ctx context.Context, sess *auth.Session, domainId int64) error {
+	if s.quota.License == "" {
+		return nil
+	}
+
+	if sess != nil {
+		if sess.Domain(0) != domainId || !sess.HasLicense(s.quota.License) {
+			return fmt.Errorf("%w: %s", ErrMeetingLicense, s.quota.License)
+		}
+
+		return nil
+	}
+
+	if s.auth == nil {
+		return fmt.Errorf("%w: %s", ErrMeetingLicense, s.quota.License)
+	}
+
+	ok, err := s.auth.DomainLicense(ctx, domainId, s.quota.License)
+	if err != nil {
+		return fmt.Errorf("failed to check domain license: %w", err)
+	}
+
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrMeetingLicense, s.quota.License)
+	}
+
+	return nil
+}
+
+// MeetingUsage returns the current usage of the domain meetings against the quota.
+func (s *MeetingService) MeetingUsage(ctx context.Context, domainId int64) (*model.MeetingUsage, error) {
+	now := time.Now()
+
+	active, err := s.store.CountActive(ctx, domainId, now.Unix())
+	if err != nil {
+		return nil, err
+	}
+
+	today, err := s.store.DailyUsage(ctx, domainId, model.UsageDay(now))
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.MeetingUsage{
+		DomainId: domainId,
+		Active:   active,
+		Today:    today,
+		Quota:    *s.quota,
+	}, nil
+}
+
+// checkQuota returns ErrMeetingQuota when a limit of the domain is already reached, before the chat is started.
+// The quota itself is taken by the store with the meeting insert.
+func (s *MeetingService) checkQuota(ctx context.Context, domainId int64, now time.Time) error {
+	if s.quota.MaxActive > 0 {
+		active, err := s.store.CountActive(ctx, domainId, now.Unix())
+		if err != nil {
+			return err
+		}
+
+		if active >= s.quota.MaxActive {
+			return fmt.Errorf("%w: %d active meetings", ErrMeetingQuota, s.quota.MaxActive)
+		}
+	}
+
+	if s.quota.MaxDaily > 0 {
+		today, err := s.store.DailyUsage(ctx, domainId, model.UsageDay(now))
+		if err != nil {
+			return err
+		}
+
+		if today >= s.quota.MaxDaily {
+			return fmt.Errorf("%w: %d meetings per day", ErrMeetingQuota, s.quota.MaxDaily)
+		}
 	}
+
+	return nil
 }
 
-func (s *MeetingService) CreateMeeting(ctx context.Context, domainId int64, title string, expireSec int64, basePath string, vars map[string]string) (string, string, error) {
+// CreateMeeting stores the meeting and, with the chat options, starts the customer conversation linked to it.
+// Returns the public meeting id.
+func (s *MeetingService) CreateMeeting(ctx context.Context, domainId int64, title string, expireSec int64, basePath string,
+	vars map[string]string, chat *model.MeetingChat,
+) (string, *model.Meeting, error) {
+	if chat != nil && strings.TrimSpace(chat.Connection) == "" {
+		return "", nil, fmt.Errorf("%w: chat connection is required", ErrMeetingInvalid)
+	}
+
 	uuid, err := gonanoid.New()
 	if err != nil {
-		return "", "", err
+		return "", nil, err
+	}
+
+	createdAt := time.Now()
+	if err = s.checkQuota(ctx, domainId, createdAt); err != nil {
+		return "", nil, err
 	}
 
-	now := time.Now().Unix()
+	now := createdAt.Unix()
 	expiresAt := now + expireSec
 	if expireSec <= 0 {
 		expiresAt = now + 86400 // 24 hours default
 	}
 
-	encryptedUuid, err := s.encrypter.Encrypt([]byte(uuid))
+	token, err := s.encodeToken(uuid)
 	if err != nil {
-		return "", "", fmt.Errorf("failed to encrypt meeting id: %w", err)
+		return "", nil, err
 	}
-	token := base64.URLEncoding.EncodeToString(encryptedUuid)
 
 	url := fmt.Sprintf("%s/%s", basePath, token)
 
@@ -80,98 +261,1143 @@ func (s *MeetingService) CreateMeeting(ctx context.Context, domainId int64, titl
 		Url:       url,
 	}
 
-	if err := s.store.Create(ctx, meeting); err != nil {
-		return "", "", err
+	if chat != nil {
+		convId, channelId, err := s.chat.StartConversation(ctx, domainId, uuid, chat, vars)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to start meeting chat: %w", err)
+		}
+
+		meeting.ConversationId = &convId
+		meeting.ChatChannelId = &channelId
+	}
+
+	// the quota is taken with the insert, so a failed creation does not count
+	created, err := s.store.Create(ctx, meeting, *s.quota)
+	if err == nil && !created {
+		err = fmt.Errorf("%w: %d active or %d daily meetings", ErrMeetingQuota, s.quota.MaxActive, s.quota.MaxDaily)
+	}
+
+	if err != nil {
+		if meeting.HasChat() {
+			if closeErr := s.chat.CloseChat(ctx, *meeting.ConversationId, *meeting.ChatChannelId, 0, ""); closeErr != nil {
+				s.log.Error("failed to close meeting chat", wlog.Err(closeErr), wlog.String("conversation_id", *meeting.ConversationId))
+			}
+		}
+
+		return "", nil, err
+	}
+
+	data := map[string]any{
+		"title":      meeting.Title,
+		"url":        meeting.Url,
+		"expires_at": meeting.ExpiresAt,
+		"variables":  meeting.Variables,
 	}
+	if meeting.ConversationId != nil {
+		data["conversation_id"] = *meeting.ConversationId
+	}
+
+	s.notify(ctx, model.EventMeetingCreated, token, meeting, data)
+	s.chatNotice(ctx, meeting, model.ChatNoticeLinkSent, nil)
 
-	return token, url, nil
+	return token, meeting, nil
 }
 
-func (s *MeetingService) GetMeeting(ctx context.Context, meetingId string) (*model.Meeting, error) {
-	id, err := s.decodeToken(meetingId)
+// OpenMeeting returns the meeting opened by the customer; the first opening is posted into the meeting chat.
+func (s *MeetingService) OpenMeeting(ctx context.Context, meetingId string) (*model.Meeting, error) {
+	meeting, err := s.GetMeeting(ctx, meetingId)
+	if err != nil || meeting == nil {
+		return meeting, err
+	}
+
+	if meeting.ConversationId == nil || !s.notices.Enabled(model.ChatNoticeLinkOpened) {
+		return meeting, nil
+	}
+
+	first, err := s.store.SetOpened(ctx, meeting.Id, time.Now().Unix())
 	if err != nil {
-		return nil, err
+		s.log.Error("failed to set meeting opened", wlog.Err(err), wlog.String("meeting_id", meeting.Id))
+		return meeting, nil
 	}
 
-	meeting, err := s.store.Get(ctx, id)
+	if first {
+		s.chatNotice(ctx, meeting, model.ChatNoticeLinkOpened, nil)
+	}
+
+	return meeting, nil
+}
+
+// JoinMeeting issues the credential of the meeting participant. The valid credential of the meeting
+// is renewed for the same participant, without a credential a new participant joins; an expired,
+// revoked or forged credential fails with ErrGuestInvalid.
+func (s *MeetingService) JoinMeeting(ctx context.Context, meetingId, guestToken string) (*model.GuestCredential, error) {
+	meeting, err := s.GetMeeting(ctx, meetingId)
 	if err != nil {
-		s.log.Error(err.Error(), wlog.Err(err))
-		return nil, nil
+		return nil, err
 	}
+
 	if meeting == nil {
-		return nil, nil // Not found in DB
+		return nil, ErrMeetingNotFound
 	}
 
-	return meeting, nil
+	now := time.Now()
+	if meeting.State == model.MeetingStateEnded || now.Unix() > meeting.ExpiresAt {
+		return nil, ErrMeetingClosed
+	}
+
+	var participant *model.Participant
+	if guestToken != "" {
+		if participant, err = s.participant(ctx, meeting, guestToken); err != nil {
+			return nil, err
+		}
+	}
+
+	if participant == nil {
+		id, err := gonanoid.New()
+		if err != nil {
+			return nil, err
+		}
+
+		participant = &model.Participant{
+			Id:        id,
+			MeetingId: meeting.Id,
+			CreatedAt: now.Unix(),
+		}
+
+		if err = s.store.CreateParticipant(ctx, participant); err != nil {
+			return nil, err
+		}
+	}
+
+	token, exp := s.guests.Issue(meeting.Id, participant.Id, now)
+
+	return &model.GuestCredential{
+		ParticipantId: participant.Id,
+		Token:         token,
+		ExpiresAt:     exp,
+	}, nil
 }
 
-func (s *MeetingService) DeleteMeeting(ctx context.Context, meetingId string) error {
-	id, err := s.decodeToken(meetingId)
+// RevokeMeetingParticipant stops accepting the credentials of the participant of the domain meeting.
+func (s *MeetingService) RevokeMeetingParticipant(ctx context.Context, domainId int64, meetingId, participantId string) error {
+	meeting, err := s.GetMeeting(ctx, meetingId)
+	if err != nil {
+		return err
+	}
+
+	if meeting == nil || meeting.DomainId != domainId {
+		return ErrMeetingNotFound
+	}
+
+	found, err := s.store.RevokeParticipant(ctx, meeting.Id, participantId, time.Now().Unix())
 	if err != nil {
 		return err
 	}
 
-	return s.store.Delete(ctx, id)
+	if !found {
+		return fmt.Errorf("%w: participant %q", ErrMeetingNotFound, participantId)
+	}
+
+	return nil
 }
 
-func (s *MeetingService) decodeToken(meetingId string) (string, error) {
-	encryptedUuid, err := base64.URLEncoding.DecodeString(meetingId)
+// participant returns the meeting participant of the credential, ErrGuestInvalid when the credential
+// is expired, revoked or issued for another meeting.
+func (s *MeetingService) participant(ctx context.Context, meeting *model.Meeting, guestToken string) (*model.Participant, error) {
+	id, err := s.guests.Verify(guestToken, meeting.Id, time.Now())
 	if err != nil {
-		return "", fmt.Errorf("invalid token format: %w", err)
+		return nil, fmt.Errorf("%w: %w", ErrGuestInvalid, err)
 	}
-	uuidBytes, err := s.encrypter.Decrypt(encryptedUuid)
+
+	p, err := s.store.GetParticipant(ctx, id)
 	if err != nil {
-		return "", fmt.Errorf("invalid token: %w", err)
+		return nil, err
 	}
 
-	return string(uuidBytes), nil
+	if p == nil || p.MeetingId != meeting.Id || p.IsRevoked() {
+		return nil, fmt.Errorf("%w: participant %q is revoked", ErrGuestInvalid, id)
+	}
+
+	return p, nil
 }
 
-func (s *MeetingService) CloseByCall(ctx context.Context, meetingId, callId string, bridged bool) (string, error) {
-	id, err := s.decodeToken(meetingId)
+// SendMeetingMessage sends the customer text message to the meeting chat.
+func (s *MeetingService) SendMeetingMessage(ctx context.Context, meetingId, guestToken, text string) (*model.ChatMessage, error) {
+	text = strings.TrimSpace(text)
+	if text == "" || len(text) > maxMessageLength {
+		return nil, fmt.Errorf("%w: message text must be 1-%d characters", ErrMeetingInvalid, maxMessageLength)
+	}
+
+	meeting, err := s.meetingChat(ctx, meetingId)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	err = s.store.SetCall(ctx, id, callId, bridged)
+	p, err := s.participant(ctx, meeting, guestToken)
 	if err != nil {
-		return id, err
+		return nil, err
+	}
+
+	if meeting.State == model.MeetingStateEnded || time.Now().Unix() > meeting.ExpiresAt {
+		return nil, ErrMeetingClosed
 	}
 
-	chatInfo, err := s.store.GetChatCloseInfo(ctx, meetingId)
+	msg, err := s.chat.SendText(ctx, *meeting.ConversationId, *meeting.ChatChannelId, text)
 	if err != nil {
-		s.log.Error(err.Error(), wlog.Err(err))
-		return id, nil
+		return nil, err
 	}
 
-	if chatInfo == nil {
-		return id, nil
+	s.log.Debug("meeting message sent", wlog.String("meeting_id", meeting.Id), wlog.String("participant_id", p.Id))
+
+	return msg, nil
+}
+
+// MeetingMessages returns the page of the meeting chat messages, newest first, and whether there are more.
+func (s *MeetingService) MeetingMessages(ctx context.Context, meetingId, guestToken string, page, size int) ([]*model.ChatMessage, bool, error) {
+	meeting, err := s.meetingChat(ctx, meetingId)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if _, err = s.participant(ctx, meeting, guestToken); err != nil {
+		return nil, false, err
+	}
+
+	page, size = normalizePage(page, size)
+
+	return s.chat.History(ctx, *meeting.ConversationId, *meeting.ChatChannelId, int32(page), int32(size))
+}
+
+// MeetingTranscript returns the domain meeting with the page of its chat messages, newest first,
+// and whether there are more.
+func (s *MeetingService) MeetingTranscript(ctx context.Context, domainId int64, meetingId string, page, size int) (*model.Meeting, []*model.ChatMessage, bool, error) {
+	meeting, err := s.domainChatMeeting(ctx, domainId, meetingId)
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	page, size = normalizePage(page, size)
+
+	list, next, err := s.chat.History(ctx, *meeting.ConversationId, meeting.CustomerChannel(), int32(page), int32(size))
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	return meeting, list, next, nil
+}
+
+// ExportMeetingTranscript renders the domain meeting with its chat messages, oldest first, as text or JSON.
+func (s *MeetingService) ExportMeetingTranscript(ctx context.Context, domainId int64, meetingId, format string) (*model.TranscriptExport, error) {
+	meeting, err := s.domainChatMeeting(ctx, domainId, meetingId)
+	if err != nil {
+		return nil, err
+	}
+
+	transcript := &model.Transcript{Meeting: meeting}
+
+	for page := 1; len(transcript.Messages) < maxTranscriptMessages; page++ {
+		list, next, err := s.chat.History(ctx, *meeting.ConversationId, meeting.CustomerChannel(), int32(page), transcriptPageSize)
+		if err != nil {
+			return nil, err
+		}
+
+		transcript.Messages = append(transcript.Messages, list...)
+		if !next {
+			break
+		}
+	}
+
+	slices.Reverse(transcript.Messages)
+
+	res, err := transcript.Export(format)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrMeetingInvalid, err)
 	}
 
-	return id, s.chat.CloseChat(ctx, chatInfo.ConversationId, chatInfo.CloserId, chatInfo.AuthUserId)
+	return res, nil
 }
 
-func (s *MeetingService) Satisfaction(ctx context.Context, meetingId, satisfaction string) error {
+// domainChatMeeting returns the domain meeting linked to a conversation.
+func (s *MeetingService) domainChatMeeting(ctx context.Context, domainId int64, meetingId string) (*model.Meeting, error) {
 	meeting, err := s.GetMeeting(ctx, meetingId)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	if meeting == nil || meeting.DomainId != domainId {
+		return nil, ErrMeetingNotFound
+	}
+
+	if meeting.ConversationId == nil {
+		return nil, ErrMeetingNoChat
+	}
+
+	return meeting, nil
+}
+
+// LinkMeetingContact links the contact to the meeting conversation, or creates one from the conversation
+// and the meeting variables when the contact id is empty. Returns the contact id stored on the meeting.
+func (s *MeetingService) LinkMeetingContact(ctx context.Context, token string, domainId int64, meetingId, contactId string) (string, error) {
+	meeting, err := s.domainChatMeeting(ctx, domainId, meetingId)
+	if err != nil {
+		return "", err
+	}
+
+	if contactId == "" {
+		if meeting.ContactId != nil {
+			return *meeting.ContactId, nil
+		}
+
+		contactId, err = s.chat.CreateContact(ctx, token, *meeting.ConversationId, meeting.ContactDraft())
+		if err != nil {
+			return "", err
+		}
+	} else {
+		if id, err := strconv.ParseInt(contactId, 10, 64); err != nil || id <= 0 {
+			return "", fmt.Errorf("%w: invalid contact id %q", ErrMeetingInvalid, contactId)
+		}
+
+		if meeting.ContactId != nil && *meeting.ContactId == contactId {
+			return contactId, nil
+		}
+
+		if err = s.chat.LinkContact(ctx, *meeting.ConversationId, contactId); err != nil {
+			return "", err
+		}
+	}
+
+	if err = s.store.SetContact(ctx, meeting.Id, contactId); err != nil {
+		return "", err
+	}
+
+	return contactId, nil
+}
+
+// meetingChat returns the meeting with the customer chat.
+func (s *MeetingService) meetingChat(ctx context.Context, meetingId string) (*model.Meeting, error) {
+	meeting, err := s.GetMeeting(ctx, meetingId)
+	if err != nil {
+		return nil, err
 	}
 
 	if meeting == nil {
-		return errors.New("meeting not found")
+		return nil, ErrMeetingNotFound
 	}
 
-	if !meeting.AllowSatisfaction() || satisfaction == "" {
-		return fmt.Errorf("not allow")
+	if !meeting.HasChat() {
+		return nil, ErrMeetingNoChat
 	}
 
-	err = s.call.SetVariables(ctx, meeting.DomainId, *meeting.CallId, map[string]string{
-		model.MeetingSatisfactionVarName: satisfaction,
-	})
+	return meeting, nil
+}
+
+func (s *MeetingService) GetMeeting(ctx context.Context, meetingId string) (*model.Meeting, error) {
+	id, err := s.decodeToken(meetingId)
 	if err != nil {
-		// TODO
+		return nil, err
+	}
+
+	meeting, err := s.store.Get(ctx, id)
+	if err != nil {
+		s.log.Error(err.Error(), wlog.Err(err))
+		return nil, nil
+	}
+	if meeting == nil {
+		return nil, nil // Not found in DB
+	}
+
+	if meeting.Calls, err = s.store.GetCalls(ctx, id); err != nil {
+		return nil, err
+	}
+
+	if meeting.Outcome == nil {
+		// not finished yet, or finished before the outcome was stored
+		if outcome := s.outcomes.Classify(meeting.Calls); outcome != "" {
+			meeting.Outcome = &outcome
+		}
+	}
+
+	return meeting, nil
+}
+
+// DeleteMeeting deletes the domain meeting, the meeting of another domain is not found.
+func (s *MeetingService) DeleteMeeting(ctx context.Context, domainId int64, meetingId string) error {
+	id, err := s.decodeToken(meetingId)
+	if err != nil {
+		return err
+	}
+
+	meeting, err := s.store.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if meeting == nil || meeting.DomainId != domainId {
+		return ErrMeetingNotFound
+	}
+
+	if err = s.store.Delete(ctx, id); err != nil {
 		return err
 	}
 
-	return s.store.SetSatisfaction(ctx, meeting.Id, satisfaction)
+	s.notify(ctx, model.EventMeetingDeleted, meetingId, meeting, nil)
+
+	return nil
+}
+
+// GetMeetingDetails returns the meeting with the engine details of its calls, read on behalf of the caller.
+// The finished calls come from the call history, the rest are read as active; the recordings are
+// returned only when allowed.
+func (s *MeetingService) GetMeetingDetails(ctx context.Context, token string, domainId int64, meetingId string, withRecordings bool) (*model.MeetingDetails, error) {
+	meeting, err := s.GetMeeting(ctx, meetingId)
+	if err != nil {
+		return nil, err
+	}
+
+	if meeting == nil || meeting.DomainId != domainId {
+		return nil, ErrMeetingNotFound
+	}
+
+	res := &model.MeetingDetails{Meeting: meeting}
+	if len(meeting.Calls) == 0 {
+		return res, nil
+	}
+
+	ids := make([]string, 0, len(meeting.Calls))
+	for _, c := range meeting.Calls {
+		ids = append(ids, c.CallId)
+	}
+
+	history, err := s.call.History(ctx, token, domainId, ids, meeting.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search call history: %w", err)
+	}
+
+	found := make(map[string]*model.CallDetails, len(history))
+	for _, c := range history {
+		found[c.Id] = c
+	}
+
+	for _, id := range ids {
+		c, ok := found[id]
+		if !ok {
+			// not stored to the history yet
+			if c, err = s.call.Active(ctx, token, domainId, id); err != nil {
+				s.log.Debug(fmt.Sprintf("skip call [%s] details: %s", id, err.Error()), wlog.String("meeting_id", meeting.Id))
+				continue
+			}
+		}
+
+		if !withRecordings {
+			c.Recordings = nil
+		}
+
+		res.Calls = append(res.Calls, c)
+	}
+
+	return res, nil
+}
+
+// StartMeetingCall originates the call to the target from the meeting variables. The call carries
+// the meeting id in its variables, so its events are applied to the meeting.
+func (s *MeetingService) StartMeetingCall(ctx context.Context, domainId int64, meetingId string) (string, error) {
+	id, err := s.decodeToken(meetingId)
+	if err != nil {
+		return "", err
+	}
+
+	meeting, err := s.store.Get(ctx, id)
+	if err != nil {
+		return "", err
+	}
+
+	if meeting == nil || meeting.DomainId != domainId {
+		return "", ErrMeetingNotFound
+	}
+
+	if time.Now().Unix() > meeting.ExpiresAt || meeting.State == model.MeetingStateEnded {
+		return "", ErrMeetingClosed
+	}
+
+	if meeting.State != "" && meeting.State != model.MeetingStateCreated {
+		return "", fmt.Errorf("%w: call already started", ErrMeetingClosed)
+	}
+
+	target, err := meeting.CallTarget()
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrMeetingInvalid, err)
+	}
+
+	if target == nil {
+		return "", fmt.Errorf("%w: call target variable is required", ErrMeetingInvalid)
+	}
+
+	callId, err := s.call.StartCall(ctx, domainId, target, s.startCallVariables(meetingId, meeting))
+	if err != nil {
+		return "", err
+	}
+
+	// the ringing event may come before the response, the link is kept by the first one
+	if err = s.store.LinkCall(ctx, id, callId, nil, time.Now().Unix()); err != nil {
+		s.log.Error("failed to link started call", wlog.Err(err), wlog.String("call_id", callId))
+	}
+
+	return callId, nil
+}
+
+// startCallVariables returns the variables of the call started for the meeting: the public meeting id
+// and, as on every linked call, the meeting variables selected by the call variables.
+func (s *MeetingService) startCallVariables(meetingId string, meeting *model.Meeting) map[string]string {
+	vars := s.callVars.Of(meeting.Variables)
+	if vars == nil {
+		vars = make(map[string]string, 1)
+	}
+	vars[model.MeetingIdVarName] = meetingId
+
+	return vars
+}
+
+// EndMeeting hangs up the active calls of the meeting with the cause on behalf of the caller,
+// closes the meeting conversation and ends the meeting, so its link can't be used again.
+func (s *MeetingService) EndMeeting(ctx context.Context, token string, domainId int64, meetingId, cause string) error {
+	id, err := s.decodeToken(meetingId)
+	if err != nil {
+		return err
+	}
+
+	meeting, err := s.store.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if meeting == nil || meeting.DomainId != domainId {
+		return ErrMeetingNotFound
+	}
+
+	if cause == "" {
+		cause = defaultHangupCause
+	}
+
+	calls, err := s.store.GetCalls(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	for _, c := range calls {
+		if c.IsLeg() || c.EndedAt != nil {
+			continue
+		}
+
+		legs, err := s.call.ActiveCalls(ctx, token, domainId, c.CallId)
+		if err != nil {
+			return fmt.Errorf("failed to search active calls: %w", err)
+		}
+
+		for _, callId := range append(legs, c.CallId) {
+			if err = s.call.Hangup(ctx, token, domainId, callId, cause); err != nil {
+				// the call may have ended meanwhile
+				s.log.Warn("failed to hangup meeting call", wlog.Err(err), wlog.String("call_id", callId))
+			}
+		}
+	}
+
+	if err = s.closeChat(ctx, meeting); err != nil {
+		return err
+	}
+
+	if err = s.store.End(ctx, id, time.Now().Unix()); err != nil {
+		return err
+	}
+
+	s.notify(ctx, model.EventMeetingEnded, meetingId, meeting, map[string]any{
+		"cause": cause,
+	})
+
+	return nil
+}
+
+// HoldMeetingCall puts the current meeting call on hold on behalf of the caller.
+func (s *MeetingService) HoldMeetingCall(ctx context.Context, token string, domainId int64, meetingId string) (string, error) {
+	return s.controlCall(ctx, domainId, meetingId, model.MeetingStateOnHold, func(callId string) error {
+		return s.call.Hold(ctx, token, domainId, callId)
+	})
+}
+
+// UnHoldMeetingCall resumes the current meeting call on behalf of the caller.
+func (s *MeetingService) UnHoldMeetingCall(ctx context.Context, token string, domainId int64, meetingId string) (string, error) {
+	return s.controlCall(ctx, domainId, meetingId, model.MeetingStateAgentConnected, func(callId string) error {
+		return s.call.UnHold(ctx, token, domainId, callId)
+	})
+}
+
+// BlindTransferMeetingCall transfers the current meeting call to the destination; the customer waits for the next agent.
+func (s *MeetingService) BlindTransferMeetingCall(ctx context.Context, token string, domainId int64, meetingId, destination string) (string, error) {
+	if strings.TrimSpace(destination) == "" {
+		return "", fmt.Errorf("%w: destination is required", ErrMeetingInvalid)
+	}
+
+	return s.controlCall(ctx, domainId, meetingId, model.MeetingStateCustomerWaiting, func(callId string) error {
+		return s.call.BlindTransfer(ctx, token, domainId, callId, destination)
+	})
+}
+
+// DtmfMeetingCall sends the DTMF digits to the current meeting call.
+func (s *MeetingService) DtmfMeetingCall(ctx context.Context, token string, domainId int64, meetingId, digits string) (string, error) {
+	if digits == "" || strings.Trim(digits, dtmfDigits) != "" {
+		return "", fmt.Errorf("%w: invalid dtmf digits %q", ErrMeetingInvalid, digits)
+	}
+
+	return s.controlCall(ctx, domainId, meetingId, "", func(callId string) error {
+		return s.call.Dtmf(ctx, token, domainId, callId, digits)
+	})
+}
+
+// controlCall applies the action to the current meeting call and moves the meeting to the state, if set.
+// Returns the call id.
+func (s *MeetingService) controlCall(ctx context.Context, domainId int64, meetingId string, state model.MeetingState, action func(callId string) error) (string, error) {
+	meeting, err := s.GetMeeting(ctx, meetingId)
+	if err != nil {
+		return "", err
+	}
+
+	if meeting == nil || meeting.DomainId != domainId {
+		return "", ErrMeetingNotFound
+	}
+
+	call := meeting.CurrentCall()
+	if call == nil || meeting.State == model.MeetingStateEnded {
+		return "", fmt.Errorf("%w: no active call", ErrMeetingClosed)
+	}
+
+	if err = action(call.CallId); err != nil {
+		return "", err
+	}
+
+	if state != "" {
+		if err = s.store.SetState(ctx, meeting.Id, state, time.Now().UnixMilli()); err != nil {
+			// the call event brings the state anyway
+			s.log.Error("failed to set meeting state", wlog.Err(err), wlog.String("meeting_id", meeting.Id))
+		}
+	}
+
+	return call.CallId, nil
+}
+
+func (s *MeetingService) encodeToken(id string) (string, error) {
+	encryptedUuid, err := s.encrypter.Encrypt([]byte(id))
+	if err != nil {
+		return "", fmt.Errorf("failed to encrypt meeting id: %w", err)
+	}
+
+	return base64.URLEncoding.EncodeToString(encryptedUuid), nil
+}
+
+func (s *MeetingService) decodeToken(meetingId string) (string, error) {
+	encryptedUuid, err := base64.URLEncoding.DecodeString(meetingId)
+	if err != nil {
+		return "", fmt.Errorf("%w format: %w", ErrInvalidToken, err)
+	}
+	uuidBytes, err := s.encrypter.Decrypt(encryptedUuid)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidToken, err)
+	}
+
+	return string(uuidBytes), nil
+}
+
+// ProcessCall applies the call event to the linked meeting exactly once. Redelivered events
+// are recognized by the inbox and skipped; a failed event is released to be processed again.
+// The event claimed by the process which died before completing it is claimed again once
+// the claim lease is over; till then its redelivery fails with model.ErrCallEventClaimed and is retried.
+func (s *MeetingService) ProcessCall(ctx context.Context, c *model.Call) (string, error) {
+	id, err := s.meetingIdByCall(ctx, c)
+	if err != nil || id == "" {
+		return id, err
+	}
+
+	if c.Timestamp == 0 {
+		// without the engine timestamp a redelivery can't be told apart
+		return id, fmt.Errorf("%w: call [%s] %s event without timestamp", ErrCallInvalid, c.Id, c.Event)
+	}
+
+	claimed, err := s.store.ClaimCallEvent(ctx, id, c.Id, c.Event, c.AtMilli())
+	if err != nil {
+		return id, err
+	}
+
+	if !claimed {
+		s.log.Debug(fmt.Sprintf("skip duplicate call [%s] %s event", c.Id, c.Event), wlog.String("meeting_id", id))
+		return id, nil
+	}
+
+	if err = s.applyCall(ctx, id, c); err != nil {
+		if relErr := s.store.ReleaseCallEvent(ctx, c.Id, c.Event, c.AtMilli()); relErr != nil {
+			s.log.Error("failed to release call event", wlog.Err(relErr), wlog.String("call_id", c.Id))
+		}
+
+		return id, err
+	}
+
+	// the event is applied, so it is acknowledged anyway; the expired claim lets a redelivery apply it again
+	if err = s.store.CompleteCallEvent(ctx, c.Id, c.Event, c.AtMilli()); err != nil {
+		s.log.Error("failed to complete call event", wlog.Err(err), wlog.String("call_id", c.Id))
+	}
+
+	return id, nil
+}
+
+// applyCall stores the hangup of every call leg, then updates the meeting by the event.
+func (s *MeetingService) applyCall(ctx context.Context, id string, c *model.Call) error {
+	if c.Event != model.CallEventHangup {
+		return s.callEvent(ctx, id, c)
+	}
+
+	if err := s.endCall(ctx, id, c); err != nil {
+		return err
+	}
+
+	outcome, err := s.classify(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if c.Data.IsParent {
+		return s.callEvent(ctx, id, c)
+	}
+
+	return s.closeByCall(ctx, id, c, outcome)
+}
+
+// endCall records the cause and the talk time of the finished call leg.
+func (s *MeetingService) endCall(ctx context.Context, id string, c *model.Call) error {
+	at := c.At()
+	talkSec := max(c.Data.TalkSec, 0)
+
+	return s.store.EndCall(ctx, id, &model.MeetingCall{
+		CallId:    c.Id,
+		ParentId:  c.Data.ParentId,
+		Cause:     c.Data.Cause,
+		TalkSec:   talkSec,
+		StartedAt: at - int64(talkSec),
+		EndedAt:   &at,
+	})
+}
+
+// classify stores the outcome once the customer call has ended; a later leg hangup may still change it.
+func (s *MeetingService) classify(ctx context.Context, id string) (model.MeetingOutcome, error) {
+	calls, err := s.store.GetCalls(ctx, id)
+	if err != nil {
+		return "", err
+	}
+
+	outcome := s.outcomes.Classify(calls)
+	if outcome == "" || outcome == model.MeetingOutcomeInProgress {
+		return outcome, nil
+	}
+
+	return outcome, s.store.SetOutcome(ctx, id, outcome)
+}
+
+// closeByCall stores the finished call leg and closes the meeting conversation.
+// A leg hangup older than the stored one, or a non-bridged leg after a bridged one, is ignored.
+func (s *MeetingService) closeByCall(ctx context.Context, id string, c *model.Call, outcome model.MeetingOutcome) error {
+	bridged := c.Data.TalkSec > 0
+
+	applied, err := s.store.SetCall(ctx, id, c.Id, bridged, c.AtMilli())
+	if err != nil {
+		return err
+	}
+
+	if !applied {
+		s.log.Debug(fmt.Sprintf("skip stale hangup of call [%s]", c.Id), wlog.String("meeting_id", id))
+		return nil
+	}
+
+	meeting, err := s.store.Get(ctx, id)
+	if err != nil || meeting == nil {
+		return err
+	}
+
+	token, err := s.callToken(id, c)
+	if err != nil {
+		return err
+	}
+
+	s.notify(ctx, model.EventMeetingCallEnded, token, meeting, map[string]any{
+		"call_id": c.Id,
+		"bridged": bridged,
+		"cause":   c.Data.Cause,
+		"outcome": outcome,
+	})
+
+	if bridged {
+		s.chatNotice(ctx, meeting, model.ChatNoticeCallEnded, map[string]string{
+			"duration": model.FormatDuration(c.Data.TalkSec),
+		})
+	}
+
+	return s.afterCallChat(ctx, meeting, bridged)
+}
+
+// afterCallChat applies the domain chat policy once the agent call ends: the chat is closed
+// or transferred right away, or after the policy delay.
+func (s *MeetingService) afterCallChat(ctx context.Context, meeting *model.Meeting, bridged bool) error {
+	if meeting.ConversationId == nil {
+		return nil
+	}
+
+	policy, err := s.chatPolicy(ctx, meeting.DomainId)
+	if err != nil {
+		return err
+	}
+
+	if !policy.Applies(bridged) {
+		return nil
+	}
+
+	if policy.DelaySec > 0 {
+		return s.store.ScheduleChatClose(ctx, meeting.Id, time.Now().Unix()+int64(policy.DelaySec))
+	}
+
+	return s.finishChat(ctx, meeting, policy)
+}
+
+// closeChat closes or transfers the meeting chat at once, unless the domain policy keeps it open.
+func (s *MeetingService) closeChat(ctx context.Context, meeting *model.Meeting) error {
+	if meeting.ConversationId == nil {
+		return nil
+	}
+
+	policy, err := s.chatPolicy(ctx, meeting.DomainId)
+	if err != nil {
+		return err
+	}
+
+	if policy.Mode == model.ChatCloseNever {
+		return nil
+	}
+
+	return s.finishChat(ctx, meeting, policy)
+}
+
+// LinkConversation records the conversation the chat widget started for the meeting created without
+// the chat options, so it is closed with the meeting. The conversation must be an open one of the meeting domain,
+// the conversation already linked is kept. Returns the conversation id stored on the meeting.
+func (s *MeetingService) LinkConversation(ctx context.Context, meetingId, conversationId string) (string, error) {
+	if strings.TrimSpace(conversationId) == "" {
+		return "", fmt.Errorf("%w: conversation id is required", ErrMeetingInvalid)
+	}
+
+	id, err := s.decodeToken(meetingId)
+	if err != nil {
+		return "", err
+	}
+
+	meeting, err := s.store.Get(ctx, id)
+	if err != nil {
+		return "", err
+	}
+
+	if meeting == nil {
+		return "", ErrMeetingNotFound
+	}
+
+	if meeting.ConversationId != nil {
+		return *meeting.ConversationId, nil
+	}
+
+	if meeting.EndedAt != nil {
+		return "", ErrMeetingClosed
+	}
+
+	chatInfo, err := s.chat.CloseInfo(ctx, meeting.DomainId, conversationId)
+	if err != nil {
+		return "", err
+	}
+
+	if chatInfo == nil {
+		return "", fmt.Errorf("%w: conversation %s is not open in the meeting domain", ErrMeetingInvalid, conversationId)
+	}
+
+	if err = s.store.SetConversation(ctx, id, conversationId); err != nil {
+		return "", err
+	}
+
+	return conversationId, nil
+}
+
+// finishChat closes the open conversation linked to the meeting with the policy cause,
+// or transfers it to the policy schema.
+func (s *MeetingService) finishChat(ctx context.Context, meeting *model.Meeting, policy *model.ChatPolicy) error {
+	chatInfo, err := s.chat.CloseInfo(ctx, meeting.DomainId, *meeting.ConversationId)
+	if err != nil {
+		s.log.Error(err.Error(), wlog.Err(err), wlog.String("meeting_id", meeting.Id))
+		return nil
+	}
+
+	if chatInfo == nil {
+		return nil
+	}
+
+	if policy.Mode == model.ChatCloseTransfer {
+		return s.chat.TransferChat(ctx, chatInfo.ConversationId, chatInfo.CloserId, policy.SchemaId)
+	}
+
+	return s.chat.CloseChat(ctx, chatInfo.ConversationId, chatInfo.CloserId, chatInfo.AuthUserId, policy.Cause)
+}
+
+// RunChatCloser closes or transfers the meeting chats whose policy delay has passed, until the context is done.
+func (s *MeetingService) RunChatCloser(ctx context.Context) {
+	ticker := time.NewTicker(chatCloseInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.closeDueChats(ctx)
+		}
+	}
+}
+
+func (s *MeetingService) closeDueChats(ctx context.Context) {
+	ids, err := s.store.TakeChatCloses(ctx, time.Now().Unix(), chatCloseBatch)
+	if err != nil {
+		s.log.Error("failed to take due meeting chats", wlog.Err(err))
+		return
+	}
+
+	for _, id := range ids {
+		meeting, err := s.store.Get(ctx, id)
+		if err != nil || meeting == nil {
+			continue
+		}
+
+		// the policy may have changed during the delay
+		if err = s.closeChat(ctx, meeting); err != nil {
+			s.log.Error("failed to close meeting chat", wlog.Err(err), wlog.String("meeting_id", id))
+		}
+	}
+}
+
+// GetChatPolicy returns the meeting chat policy of the domain.
+func (s *MeetingService) GetChatPolicy(ctx context.Context, domainId int64) (*model.ChatPolicy, error) {
+	return s.chatPolicy(ctx, domainId)
+}
+
+// UpdateChatPolicy stores the meeting chat policy of the domain.
+func (s *MeetingService) UpdateChatPolicy(ctx context.Context, p *model.ChatPolicy) (*model.ChatPolicy, error) {
+	if p.Cause == "" {
+		p.Cause = model.DefaultChatPolicy(p.DomainId).Cause
+	}
+
+	if err := p.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrMeetingInvalid, err)
+	}
+
+	p.UpdatedAt = time.Now().UnixMilli()
+	if err := s.store.SetChatPolicy(ctx, p); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+func (s *MeetingService) chatPolicy(ctx context.Context, domainId int64) (*model.ChatPolicy, error) {
+	p, err := s.store.GetChatPolicy(ctx, domainId)
+	if err != nil {
+		return nil, err
+	}
+
+	if p == nil {
+		return model.DefaultChatPolicy(domainId), nil
+	}
+
+	return p, nil
+}
+
+// callEvent applies a live call event to the meeting state, so the meeting reflects
+// whether the customer is waiting, talking with an agent or on hold.
+func (s *MeetingService) callEvent(ctx context.Context, id string, c *model.Call) error {
+	at := c.AtMilli()
+
+	switch c.Event {
+	case model.CallEventRinging:
+		if err := s.store.LinkCall(ctx, id, c.Id, c.Data.ParentId, c.At()); err != nil {
+			return err
+		}
+
+		s.pushVariables(ctx, id, c.Id)
+
+		if c.IsLeg() {
+			// the agent is offered the call, the customer keeps waiting
+			return nil
+		}
+
+		return s.store.SetState(ctx, id, model.MeetingStateCustomerWaiting, at)
+
+	case model.CallEventActive:
+		if c.IsLeg() {
+			return s.store.SetState(ctx, id, model.MeetingStateAgentConnected, at)
+		}
+
+		meeting, err := s.store.Get(ctx, id)
+		if err != nil || meeting == nil {
+			return err
+		}
+
+		if meeting.State == model.MeetingStateOnHold {
+			return s.store.SetState(ctx, id, model.MeetingStateAgentConnected, at)
+		}
+
+		return s.store.SetState(ctx, id, model.MeetingStateCustomerWaiting, at)
+
+	case model.CallEventBridge:
+		if err := s.store.SetBridged(ctx, id, at); err != nil {
+			return err
+		}
+
+		if c.IsLeg() && s.notices.Enabled(model.ChatNoticeCallConnected) {
+			if meeting, _ := s.store.Get(ctx, id); meeting != nil {
+				s.chatNotice(ctx, meeting, model.ChatNoticeCallConnected, nil)
+			}
+		}
+
+		return nil
+
+	case model.CallEventHold:
+		return s.store.SetState(ctx, id, model.MeetingStateOnHold, at)
+
+	case model.CallEventHangup:
+		return s.store.SetState(ctx, id, model.MeetingStateEnded, at)
+	}
+
+	return nil
+}
+
+// pushVariables sets the selected meeting variables on the linked call.
+// The call goes on without them, so a failure is only logged.
+func (s *MeetingService) pushVariables(ctx context.Context, id, callId string) {
+	meeting, err := s.store.Get(ctx, id)
+	if err != nil || meeting == nil {
+		return
+	}
+
+	vars := s.callVars.Of(meeting.Variables)
+	if len(vars) == 0 {
+		return
+	}
+
+	if err = s.call.SetVariables(ctx, meeting.DomainId, callId, vars); err != nil {
+		s.log.Warn("failed to set meeting variables", wlog.Err(err), wlog.String("meeting_id", id),
+			wlog.String("call_id", callId))
+	}
+}
+
+// callToken returns the public meeting id for the event subscribers.
+func (s *MeetingService) callToken(id string, c *model.Call) (string, error) {
+	if c.Data.MeetingId != nil {
+		return *c.Data.MeetingId, nil
+	}
+
+	return s.encodeToken(id)
+}
+
+// meetingIdByCall resolves the meeting from the call variables, or the leg from its linked parent call.
+// The meeting call carries meeting_id, so the store is queried only for a leg without it; any other call
+// of the shared exchange is skipped from the payload.
+func (s *MeetingService) meetingIdByCall(ctx context.Context, c *model.Call) (string, error) {
+	if c.Data.MeetingId != nil && *c.Data.MeetingId != "" {
+		return s.decodeToken(*c.Data.MeetingId)
+	}
+
+	if !c.IsLeg() {
+		return "", nil
+	}
+
+	return s.store.FindByCall(ctx, *c.Data.ParentId)
+}
+
+func (s *MeetingService) Satisfaction(ctx context.Context, meetingId, guestToken, satisfaction string) error {
+	meeting, err := s.GetMeeting(ctx, meetingId)
+	if err != nil {
+		return err
+	}
+
+	if meeting == nil {
+		return errors.New("meeting not found")
+	}
+
+	p, err := s.participant(ctx, meeting, guestToken)
+	if err != nil {
+		return err
+	}
+
+	if !meeting.AllowSatisfaction() || satisfaction == "" {
+		return fmt.Errorf("not allow")
+	}
+
+	err = s.call.SetVariables(ctx, meeting.DomainId, *meeting.CallId, map[string]string{
+		model.MeetingSatisfactionVarName: satisfaction,
+	})
+	if err != nil {
+		// TODO
+		return err
+	}
+
+	if err = s.store.SetSatisfaction(ctx, meeting.Id, satisfaction); err != nil {
+		return err
+	}
+
+	s.notify(ctx, model.EventMeetingSatisfaction, meetingId, meeting, map[string]any{
+		"call_id":        *meeting.CallId,
+		"satisfaction":   satisfaction,
+		"participant_id": p.Id,
+	})
+	s.chatNotice(ctx, meeting, model.ChatNoticeSatisfaction, map[string]string{
+		"satisfaction": satisfaction,
+	})
+
+	return nil
+}
+
+// chatNotice posts the notice into the meeting chat in the meeting language. The meeting goes on
+// without it, so a failure is only logged.
+func (s *MeetingService) chatNotice(ctx context.Context, meeting *model.Meeting, notice model.ChatNotice, args map[string]string) {
+	if meeting.ConversationId == nil {
+		return
+	}
+
+	vars := map[string]string{
+		"title": meeting.Title,
+		"url":   meeting.Url,
+	}
+	for k, v := range args {
+		vars[k] = v
+	}
+
+	text, ok := s.notices.Text(notice, meeting.Variables[model.MeetingVarLanguage], vars)
+	if !ok {
+		return
+	}
+
+	if err := s.chat.SendNotice(ctx, *meeting.ConversationId, text); err != nil {
+		s.log.Warn("failed to send meeting chat notice", wlog.Err(err), wlog.String("meeting_id", meeting.Id),
+			wlog.String("notice", string(notice)))
+	}
+}
+
+// notify publishes the meeting lifecycle event to the domain subscribers.
+func (s *MeetingService) notify(ctx context.Context, event, token string, meeting *model.Meeting, data map[string]any) {
+	if s.webhook == nil {
+		return
+	}
+
+	s.webhook.Dispatch(ctx, &model.MeetingEvent{
+		Type:      event,
+		DomainId:  meeting.DomainId,
+		MeetingId: token,
+		Data:      data,
+	})
 }
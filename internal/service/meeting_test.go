@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"github.com/webitel/web-meeting-backend/infra/auth"
 	"github.com/webitel/web-meeting-backend/infra/encrypter"
 	"github.com/webitel/web-meeting-backend/internal/model"
 	"github.com/webitel/wlog"
@@ -18,9 +19,9 @@ type MockMeetingStore struct {
 	mock.Mock
 }
 
-func (m *MockMeetingStore) Create(ctx context.Context, meeting *model.Meeting) error {
-	args := m.Called(ctx, meeting)
-	return args.Error(0)
+func (m *MockMeetingStore) Create(ctx context.Context, meeting *model.Meeting, q model.MeetingQuota) (bool, error) {
+	args := m.Called(ctx, meeting, q)
+	return args.Bool(0), args.Error(1)
 }
 
 func (m *MockMeetingStore) Get(ctx context.Context, id string) (*model.Meeting, error) {
@@ -36,9 +37,9 @@ func (m *MockMeetingStore) Delete(ctx context.Context, id string) error {
 	return args.Error(0)
 }
 
-func (m *MockMeetingStore) SetCallId(ctx context.Context, id string, callId string) error {
-	args := m.Called(ctx, id, callId)
-	return args.Error(0)
+func (m *MockMeetingStore) SetCall(ctx context.Context, id string, callId string, bridged bool, eventAt int64) (bool, error) {
+	args := m.Called(ctx, id, callId, bridged, eventAt)
+	return args.Bool(0), args.Error(1)
 }
 
 func (m *MockMeetingStore) SetSatisfaction(ctx context.Context, id string, satisfaction string) error {
@@ -46,6 +47,133 @@ func (m *MockMeetingStore) SetSatisfaction(ctx context.Context, id string, satis
 	return args.Error(0)
 }
 
+func (m *MockMeetingStore) LinkCall(ctx context.Context, id, callId string, parentId *string, at int64) error {
+	args := m.Called(ctx, id, callId, parentId, at)
+	return args.Error(0)
+}
+
+func (m *MockMeetingStore) EndCall(ctx context.Context, id string, c *model.MeetingCall) error {
+	args := m.Called(ctx, id, c)
+	return args.Error(0)
+}
+
+func (m *MockMeetingStore) GetCalls(ctx context.Context, id string) ([]*model.MeetingCall, error) {
+	args := m.Called(ctx, id)
+	if calls, ok := args.Get(0).([]*model.MeetingCall); ok {
+		return calls, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockMeetingStore) SetOutcome(ctx context.Context, id string, outcome model.MeetingOutcome) error {
+	args := m.Called(ctx, id, outcome)
+	return args.Error(0)
+}
+
+func (m *MockMeetingStore) FindByCall(ctx context.Context, callId string) (string, error) {
+	args := m.Called(ctx, callId)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockMeetingStore) SetState(ctx context.Context, id string, state model.MeetingState, eventAt int64) error {
+	args := m.Called(ctx, id, state, eventAt)
+	return args.Error(0)
+}
+
+func (m *MockMeetingStore) SetBridged(ctx context.Context, id string, eventAt int64) error {
+	args := m.Called(ctx, id, eventAt)
+	return args.Error(0)
+}
+
+func (m *MockMeetingStore) End(ctx context.Context, id string, at int64) error {
+	args := m.Called(ctx, id, at)
+	return args.Error(0)
+}
+
+func (m *MockMeetingStore) SetOpened(ctx context.Context, id string, at int64) (bool, error) {
+	args := m.Called(ctx, id, at)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockMeetingStore) SetContact(ctx context.Context, id, contactId string) error {
+	args := m.Called(ctx, id, contactId)
+	return args.Error(0)
+}
+
+func (m *MockMeetingStore) SetConversation(ctx context.Context, id, conversationId string) error {
+	args := m.Called(ctx, id, conversationId)
+	return args.Error(0)
+}
+
+func (m *MockMeetingStore) CountActive(ctx context.Context, domainId int64, now int64) (int, error) {
+	args := m.Called(ctx, domainId, now)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockMeetingStore) DailyUsage(ctx context.Context, domainId int64, day int64) (int, error) {
+	args := m.Called(ctx, domainId, day)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockMeetingStore) CreateParticipant(ctx context.Context, p *model.Participant) error {
+	args := m.Called(ctx, p)
+	return args.Error(0)
+}
+
+func (m *MockMeetingStore) GetParticipant(ctx context.Context, id string) (*model.Participant, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Participant), args.Error(1)
+}
+
+func (m *MockMeetingStore) RevokeParticipant(ctx context.Context, meetingId, id string, at int64) (bool, error) {
+	args := m.Called(ctx, meetingId, id, at)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockMeetingStore) ScheduleChatClose(ctx context.Context, id string, at int64) error {
+	args := m.Called(ctx, id, at)
+	return args.Error(0)
+}
+
+func (m *MockMeetingStore) TakeChatCloses(ctx context.Context, now int64, limit int) ([]string, error) {
+	args := m.Called(ctx, now, limit)
+	if ids, ok := args.Get(0).([]string); ok {
+		return ids, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockMeetingStore) GetChatPolicy(ctx context.Context, domainId int64) (*model.ChatPolicy, error) {
+	args := m.Called(ctx, domainId)
+	if p, ok := args.Get(0).(*model.ChatPolicy); ok {
+		return p, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockMeetingStore) SetChatPolicy(ctx context.Context, p *model.ChatPolicy) error {
+	args := m.Called(ctx, p)
+	return args.Error(0)
+}
+
+func (m *MockMeetingStore) ClaimCallEvent(ctx context.Context, id, callId, event string, eventAt int64) (bool, error) {
+	args := m.Called(ctx, id, callId, event, eventAt)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockMeetingStore) CompleteCallEvent(ctx context.Context, callId, event string, eventAt int64) error {
+	args := m.Called(ctx, callId, event, eventAt)
+	return args.Error(0)
+}
+
+func (m *MockMeetingStore) ReleaseCallEvent(ctx context.Context, callId, event string, eventAt int64) error {
+	args := m.Called(ctx, callId, event, eventAt)
+	return args.Error(0)
+}
+
 func setupMeetingService(t *testing.T) (*MeetingService, *MockMeetingStore) {
 	mockStore := new(MockMeetingStore)
 	logger := wlog.NewLogger(&wlog.LoggerConfiguration{EnableConsole: false})
@@ -55,7 +183,7 @@ func setupMeetingService(t *testing.T) (*MeetingService, *MockMeetingStore) {
 	enc, err := encrypter.New(key)
 	require.NoError(t, err)
 
-	svc := NewMeetingService(context.Background(), nil, nil, logger, mockStore, enc)
+	svc := NewMeetingService(context.Background(), nil, nil, logger, mockStore, enc, nil, nil, nil, nil, nil, nil, nil)
 	return svc, mockStore
 }
 
@@ -70,7 +198,7 @@ func TestMeetingService_CreateMeeting(t *testing.T) {
 	vars := map[string]string{"key": "value"}
 
 	// Expect Create to be called
-	mockStore.On("Create", ctx, mock.AnythingOfType("*model.Meeting")).Return(nil).Run(func(args mock.Arguments) {
+	mockStore.On("Create", ctx, mock.AnythingOfType("*model.Meeting"), model.MeetingQuota{}).Return(true, nil).Run(func(args mock.Arguments) {
 		meeting := args.Get(1).(*model.Meeting)
 		assert.NotEmpty(t, meeting.Id)
 		assert.Equal(t, domainID, meeting.DomainId)
@@ -80,11 +208,11 @@ func TestMeetingService_CreateMeeting(t *testing.T) {
 		assert.Contains(t, meeting.Url, basePath)
 	})
 
-	token, url, err := svc.CreateMeeting(ctx, domainID, title, expireSec, basePath, vars)
+	token, meeting, err := svc.CreateMeeting(ctx, domainID, title, expireSec, basePath, vars, nil)
 	require.NoError(t, err)
 	assert.NotEmpty(t, token)
-	assert.NotEmpty(t, url)
-	assert.Contains(t, url, token)
+	assert.Contains(t, meeting.Url, token)
+	assert.Nil(t, meeting.ConversationId)
 
 	mockStore.AssertExpectations(t)
 }
@@ -95,12 +223,12 @@ func TestMeetingService_GetMeeting(t *testing.T) {
 		ctx := context.Background()
 		var generatedID string
 		// We temporarily mock Create to capture the ID
-		mockStore.On("Create", ctx, mock.Anything).Run(func(args mock.Arguments) {
+		mockStore.On("Create", ctx, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
 			m := args.Get(1).(*model.Meeting)
 			generatedID = m.Id
-		}).Return(nil)
+		}).Return(true, nil)
 
-		token, _, err := svc.CreateMeeting(ctx, 1, "Setup", 3600, "http://base", nil)
+		token, _, err := svc.CreateMeeting(ctx, 1, "Setup", 3600, "http://base", nil, nil)
 		require.NoError(t, err)
 
 		// Remove the call expectation so it doesn't interfere (or we just use fresh mocks after this helper?
@@ -123,12 +251,15 @@ func TestMeetingService_GetMeeting(t *testing.T) {
 			ExpiresAt: time.Now().Unix() + 3600,
 		}
 
+		calls := []*model.MeetingCall{{CallId: "call"}}
 		mockStore.On("Get", ctx, generatedID).Return(validMeeting, nil)
+		mockStore.On("GetCalls", ctx, generatedID).Return(calls, nil)
 
 		meeting, err := svc.GetMeeting(ctx, token)
 		require.NoError(t, err)
 		assert.NotNil(t, meeting)
 		assert.Equal(t, generatedID, meeting.Id)
+		assert.Equal(t, calls, meeting.Calls)
 		mockStore.AssertExpectations(t)
 	})
 
@@ -156,11 +287,13 @@ func TestMeetingService_GetMeeting(t *testing.T) {
 			ExpiresAt: time.Now().Unix() - 100, // Expired
 		}
 		mockStore.On("Get", ctx, generatedID).Return(expiredMeeting, nil)
+		mockStore.On("GetCalls", ctx, generatedID).Return(nil, nil)
 
+		// expiration is resolved by the handlers: a meeting with a linked call stays viewable
 		meeting, err := svc.GetMeeting(ctx, token)
-		require.Error(t, err)
-		assert.Nil(t, meeting)
-		assert.Contains(t, err.Error(), "expired")
+		require.NoError(t, err)
+		require.NotNil(t, meeting)
+		assert.Less(t, meeting.ExpiresAt, time.Now().Unix())
 		mockStore.AssertExpectations(t)
 	})
 
@@ -172,3 +305,873 @@ func TestMeetingService_GetMeeting(t *testing.T) {
 		assert.Nil(t, meeting)
 	})
 }
+
+func TestMeetingService_ProcessCall(t *testing.T) {
+	ctx := context.Background()
+	parentId := "parent"
+
+	t.Run("Customer ringing links the call", func(t *testing.T) {
+		svc, mockStore := setupMeetingService(t)
+		token, err := svc.encodeToken("meeting")
+		require.NoError(t, err)
+
+		mockStore.On("ClaimCallEvent", ctx, "meeting", "call", model.CallEventRinging, int64(1700000000000)).Return(true, nil)
+		mockStore.On("CompleteCallEvent", ctx, "call", model.CallEventRinging, int64(1700000000000)).Return(nil)
+		mockStore.On("LinkCall", ctx, "meeting", "call", (*string)(nil), int64(1700000000)).Return(nil)
+		mockStore.On("Get", ctx, "meeting").Return(&model.Meeting{Id: "meeting", DomainId: 1}, nil)
+		mockStore.On("SetState", ctx, "meeting", model.MeetingStateCustomerWaiting, int64(1700000000000)).Return(nil)
+
+		id, err := svc.ProcessCall(ctx, &model.Call{
+			Id:        "call",
+			Event:     model.CallEventRinging,
+			Timestamp: 1700000000000,
+			Data:      model.CallHangupData{MeetingId: &token},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "meeting", id)
+		mockStore.AssertExpectations(t)
+	})
+
+	t.Run("Agent leg answer resolved by parent call", func(t *testing.T) {
+		svc, mockStore := setupMeetingService(t)
+
+		mockStore.On("FindByCall", ctx, parentId).Return("meeting", nil)
+		mockStore.On("ClaimCallEvent", ctx, "meeting", "leg", model.CallEventActive, int64(200000)).Return(true, nil)
+		mockStore.On("CompleteCallEvent", ctx, "leg", model.CallEventActive, int64(200000)).Return(nil)
+		mockStore.On("SetState", ctx, "meeting", model.MeetingStateAgentConnected, int64(200000)).Return(nil)
+
+		id, err := svc.ProcessCall(ctx, &model.Call{
+			Id:        "leg",
+			Event:     model.CallEventActive,
+			Timestamp: 200,
+			Data:      model.CallHangupData{ParentId: &parentId},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "meeting", id)
+		mockStore.AssertExpectations(t)
+	})
+
+	t.Run("Resume after hold", func(t *testing.T) {
+		svc, mockStore := setupMeetingService(t)
+
+		token, err := svc.encodeToken("meeting")
+		require.NoError(t, err)
+		mockStore.On("ClaimCallEvent", ctx, "meeting", "call", model.CallEventActive, int64(300000)).Return(true, nil)
+		mockStore.On("CompleteCallEvent", ctx, "call", model.CallEventActive, int64(300000)).Return(nil)
+		mockStore.On("Get", ctx, "meeting").Return(&model.Meeting{Id: "meeting", State: model.MeetingStateOnHold}, nil)
+		mockStore.On("SetState", ctx, "meeting", model.MeetingStateAgentConnected, int64(300000)).Return(nil)
+
+		_, err = svc.ProcessCall(ctx, &model.Call{Id: "call", Event: model.CallEventActive, Timestamp: 300,
+			Data: model.CallHangupData{MeetingId: &token}})
+		require.NoError(t, err)
+		mockStore.AssertExpectations(t)
+	})
+
+	t.Run("Unknown call is skipped", func(t *testing.T) {
+		svc, mockStore := setupMeetingService(t)
+
+		id, err := svc.ProcessCall(ctx, &model.Call{Id: "other", Event: model.CallEventHold})
+		require.NoError(t, err)
+		assert.Empty(t, id)
+		mockStore.AssertNotCalled(t, "FindByCall", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Unknown leg is skipped", func(t *testing.T) {
+		svc, mockStore := setupMeetingService(t)
+		otherParent := "other-parent"
+
+		mockStore.On("FindByCall", ctx, otherParent).Return("", nil)
+
+		id, err := svc.ProcessCall(ctx, &model.Call{Id: "leg", Event: model.CallEventHold, Timestamp: 100,
+			Data: model.CallHangupData{ParentId: &otherParent}})
+		require.NoError(t, err)
+		assert.Empty(t, id)
+		mockStore.AssertExpectations(t)
+	})
+
+	t.Run("Redelivered event is skipped", func(t *testing.T) {
+		svc, mockStore := setupMeetingService(t)
+
+		token, err := svc.encodeToken("meeting")
+		require.NoError(t, err)
+		mockStore.On("ClaimCallEvent", ctx, "meeting", "call", model.CallEventHold, int64(400000)).Return(false, nil)
+
+		id, err := svc.ProcessCall(ctx, &model.Call{Id: "call", Event: model.CallEventHold, Timestamp: 400,
+			Data: model.CallHangupData{MeetingId: &token}})
+		require.NoError(t, err)
+		assert.Equal(t, "meeting", id)
+		mockStore.AssertNotCalled(t, "SetState", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Event claimed by another delivery is retried", func(t *testing.T) {
+		svc, mockStore := setupMeetingService(t)
+
+		token, err := svc.encodeToken("meeting")
+		require.NoError(t, err)
+		mockStore.On("ClaimCallEvent", ctx, "meeting", "call", model.CallEventHold, int64(400000)).Return(false, model.ErrCallEventClaimed)
+
+		_, err = svc.ProcessCall(ctx, &model.Call{Id: "call", Event: model.CallEventHold, Timestamp: 400,
+			Data: model.CallHangupData{MeetingId: &token}})
+		require.ErrorIs(t, err, model.ErrCallEventClaimed)
+		require.NotErrorIs(t, err, ErrCallInvalid)
+		mockStore.AssertNotCalled(t, "SetState", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Event without timestamp is rejected", func(t *testing.T) {
+		svc, mockStore := setupMeetingService(t)
+
+		token, err := svc.encodeToken("meeting")
+		require.NoError(t, err)
+
+		_, err = svc.ProcessCall(ctx, &model.Call{Id: "call", Event: model.CallEventHold, Data: model.CallHangupData{MeetingId: &token}})
+		require.ErrorIs(t, err, ErrCallInvalid)
+		mockStore.AssertNotCalled(t, "ClaimCallEvent", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Failed event is released", func(t *testing.T) {
+		svc, mockStore := setupMeetingService(t)
+
+		token, err := svc.encodeToken("meeting")
+		require.NoError(t, err)
+		mockStore.On("ClaimCallEvent", ctx, "meeting", "call", model.CallEventHold, int64(500000)).Return(true, nil)
+		mockStore.On("SetState", ctx, "meeting", model.MeetingStateOnHold, int64(500000)).Return(assert.AnError)
+		mockStore.On("ReleaseCallEvent", ctx, "call", model.CallEventHold, int64(500000)).Return(nil)
+
+		_, err = svc.ProcessCall(ctx, &model.Call{Id: "call", Event: model.CallEventHold, Timestamp: 500,
+			Data: model.CallHangupData{MeetingId: &token}})
+		require.ErrorIs(t, err, assert.AnError)
+		mockStore.AssertExpectations(t)
+		mockStore.AssertNotCalled(t, "CompleteCallEvent", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Stale leg hangup keeps the bridged call", func(t *testing.T) {
+		svc, mockStore := setupMeetingService(t)
+
+		mockStore.On("FindByCall", ctx, parentId).Return("meeting", nil)
+		mockStore.On("ClaimCallEvent", ctx, "meeting", "leg", model.CallEventHangup, int64(600000)).Return(true, nil)
+		mockStore.On("CompleteCallEvent", ctx, "leg", model.CallEventHangup, int64(600000)).Return(nil)
+		mockStore.On("EndCall", ctx, "meeting", mock.AnythingOfType("*model.MeetingCall")).Return(nil)
+		mockStore.On("GetCalls", ctx, "meeting").Return([]*model.MeetingCall{{CallId: parentId}}, nil)
+		mockStore.On("SetCall", ctx, "meeting", "leg", false, int64(600000)).Return(false, nil)
+
+		_, err := svc.ProcessCall(ctx, &model.Call{
+			Id:        "leg",
+			Event:     model.CallEventHangup,
+			Timestamp: 600,
+			Data:      model.CallHangupData{ParentId: &parentId},
+		})
+		require.NoError(t, err)
+		mockStore.AssertExpectations(t)
+		mockStore.AssertNotCalled(t, "Get", mock.Anything, mock.Anything)
+	})
+	t.Run("Customer hangup records the call leg and the outcome", func(t *testing.T) {
+		svc, mockStore := setupMeetingService(t)
+		cause := "NORMAL_CLEARING"
+		ended := int64(700)
+
+		token, err := svc.encodeToken("meeting")
+		require.NoError(t, err)
+		mockStore.On("ClaimCallEvent", ctx, "meeting", "call", model.CallEventHangup, int64(700000)).Return(true, nil)
+		mockStore.On("CompleteCallEvent", ctx, "call", model.CallEventHangup, int64(700000)).Return(nil)
+		mockStore.On("EndCall", ctx, "meeting", mock.AnythingOfType("*model.MeetingCall")).Return(nil).Run(func(args mock.Arguments) {
+			c := args.Get(2).(*model.MeetingCall)
+			assert.Equal(t, "call", c.CallId)
+			assert.Equal(t, &cause, c.Cause)
+			assert.Equal(t, 30, c.TalkSec)
+			assert.Equal(t, int64(670), c.StartedAt)
+			assert.Equal(t, int64(700), *c.EndedAt)
+		})
+		mockStore.On("GetCalls", ctx, "meeting").Return([]*model.MeetingCall{
+			{CallId: "call", Cause: &cause, EndedAt: &ended},
+		}, nil)
+		mockStore.On("SetOutcome", ctx, "meeting", model.MeetingOutcomeCustomerAbandoned).Return(nil)
+		mockStore.On("SetState", ctx, "meeting", model.MeetingStateEnded, int64(700000)).Return(nil)
+
+		_, err = svc.ProcessCall(ctx, &model.Call{
+			Id:        "call",
+			Event:     model.CallEventHangup,
+			Timestamp: 700,
+			Data:      model.CallHangupData{Cause: &cause, TalkSec: 30, IsParent: true, MeetingId: &token},
+		})
+		require.NoError(t, err)
+		mockStore.AssertExpectations(t)
+	})
+}
+
+func TestMeetingService_StartMeetingCall(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		meeting *model.Meeting
+		wantErr error
+	}{
+		{name: "not found", wantErr: ErrMeetingNotFound},
+		{
+			name:    "other domain",
+			meeting: &model.Meeting{Id: "meeting", DomainId: 2, ExpiresAt: time.Now().Unix() + 60},
+			wantErr: ErrMeetingNotFound,
+		},
+		{
+			name:    "expired",
+			meeting: &model.Meeting{Id: "meeting", DomainId: 1, ExpiresAt: time.Now().Unix() - 60},
+			wantErr: ErrMeetingClosed,
+		},
+		{
+			name: "call already started",
+			meeting: &model.Meeting{Id: "meeting", DomainId: 1, ExpiresAt: time.Now().Unix() + 60,
+				State: model.MeetingStateCustomerWaiting},
+			wantErr: ErrMeetingClosed,
+		},
+		{
+			name:    "no call target",
+			meeting: &model.Meeting{Id: "meeting", DomainId: 1, ExpiresAt: time.Now().Unix() + 60},
+			wantErr: ErrMeetingInvalid,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, mockStore := setupMeetingService(t)
+			token, err := svc.encodeToken("meeting")
+			require.NoError(t, err)
+
+			mockStore.On("Get", ctx, "meeting").Return(tt.meeting, nil)
+
+			_, err = svc.StartMeetingCall(ctx, 1, token)
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestMeetingService_StartCallVariables(t *testing.T) {
+	svc, _ := setupMeetingService(t)
+	meeting := &model.Meeting{Variables: map[string]string{
+		"order_id":     "42",
+		"secret":       "hidden",
+		"call_user_id": "7",
+	}}
+
+	// no meeting variables are pushed unless the operator lists them
+	assert.Equal(t, map[string]string{model.MeetingIdVarName: "token"}, svc.startCallVariables("token", meeting))
+
+	svc.callVars = model.NewCallVariables("order_id")
+	assert.Equal(t, map[string]string{
+		model.MeetingIdVarName:           "token",
+		model.CallVarPrefix + "order_id": "42",
+	}, svc.startCallVariables("token", meeting))
+}
+
+func TestMeetingService_EndMeeting(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Ends the meeting without active calls", func(t *testing.T) {
+		svc, mockStore := setupMeetingService(t)
+		token, err := svc.encodeToken("meeting")
+		require.NoError(t, err)
+
+		ended := int64(100)
+		mockStore.On("Get", ctx, "meeting").Return(&model.Meeting{Id: "meeting", DomainId: 1}, nil)
+		mockStore.On("GetCalls", ctx, "meeting").Return([]*model.MeetingCall{{CallId: "call", EndedAt: &ended}}, nil)
+		mockStore.On("End", ctx, "meeting", mock.AnythingOfType("int64")).Return(nil)
+
+		require.NoError(t, svc.EndMeeting(ctx, "access", 1, token, ""))
+		mockStore.AssertExpectations(t)
+	})
+
+	t.Run("Other domain meeting is not found", func(t *testing.T) {
+		svc, mockStore := setupMeetingService(t)
+		token, err := svc.encodeToken("meeting")
+		require.NoError(t, err)
+
+		mockStore.On("Get", ctx, "meeting").Return(&model.Meeting{Id: "meeting", DomainId: 2}, nil)
+
+		require.ErrorIs(t, svc.EndMeeting(ctx, "access", 1, token, ""), ErrMeetingNotFound)
+		mockStore.AssertNotCalled(t, "End", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestMeetingService_DeleteMeeting(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Deletes the domain meeting", func(t *testing.T) {
+		svc, mockStore := setupMeetingService(t)
+		token, err := svc.encodeToken("meeting")
+		require.NoError(t, err)
+
+		mockStore.On("Get", ctx, "meeting").Return(&model.Meeting{Id: "meeting", DomainId: 1}, nil)
+		mockStore.On("Delete", ctx, "meeting").Return(nil)
+
+		require.NoError(t, svc.DeleteMeeting(ctx, 1, token))
+		mockStore.AssertExpectations(t)
+	})
+
+	t.Run("Other domain meeting is not found", func(t *testing.T) {
+		svc, mockStore := setupMeetingService(t)
+		token, err := svc.encodeToken("meeting")
+		require.NoError(t, err)
+
+		mockStore.On("Get", ctx, "meeting").Return(&model.Meeting{Id: "meeting", DomainId: 2}, nil)
+
+		require.ErrorIs(t, svc.DeleteMeeting(ctx, 1, token), ErrMeetingNotFound)
+		mockStore.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+}
+
+func TestMeetingService_LinkConversation(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Linked conversation is kept", func(t *testing.T) {
+		svc, mockStore := setupMeetingService(t)
+		token, err := svc.encodeToken("meeting")
+		require.NoError(t, err)
+
+		convId := "linked"
+		mockStore.On("Get", ctx, "meeting").Return(&model.Meeting{Id: "meeting", DomainId: 1, ConversationId: &convId}, nil)
+
+		res, err := svc.LinkConversation(ctx, token, "widget")
+		require.NoError(t, err)
+		assert.Equal(t, "linked", res)
+		mockStore.AssertNotCalled(t, "SetConversation", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Ended meeting", func(t *testing.T) {
+		svc, mockStore := setupMeetingService(t)
+		token, err := svc.encodeToken("meeting")
+		require.NoError(t, err)
+
+		ended := int64(100)
+		mockStore.On("Get", ctx, "meeting").Return(&model.Meeting{Id: "meeting", DomainId: 1, EndedAt: &ended}, nil)
+
+		_, err = svc.LinkConversation(ctx, token, "widget")
+		require.ErrorIs(t, err, ErrMeetingClosed)
+		mockStore.AssertNotCalled(t, "SetConversation", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Conversation id is required", func(t *testing.T) {
+		svc, mockStore := setupMeetingService(t)
+		token, err := svc.encodeToken("meeting")
+		require.NoError(t, err)
+
+		_, err = svc.LinkConversation(ctx, token, " ")
+		require.ErrorIs(t, err, ErrMeetingInvalid)
+		mockStore.AssertNotCalled(t, "Get", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Meeting without conversation keeps the chat open", func(t *testing.T) {
+		svc, mockStore := setupMeetingService(t)
+
+		require.NoError(t, svc.closeChat(ctx, &model.Meeting{Id: "meeting", DomainId: 1}))
+		mockStore.AssertNotCalled(t, "GetChatPolicy", mock.Anything, mock.Anything)
+	})
+}
+
+func TestMeetingService_GetMeetingDetails(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Meeting without calls", func(t *testing.T) {
+		svc, mockStore := setupMeetingService(t)
+		token, err := svc.encodeToken("meeting")
+		require.NoError(t, err)
+
+		meeting := &model.Meeting{Id: "meeting", DomainId: 1}
+		mockStore.On("Get", ctx, "meeting").Return(meeting, nil)
+		mockStore.On("GetCalls", ctx, "meeting").Return(nil, nil)
+
+		details, err := svc.GetMeetingDetails(ctx, "access", 1, token, false)
+		require.NoError(t, err)
+		assert.Equal(t, meeting, details.Meeting)
+		assert.Empty(t, details.Calls)
+	})
+
+	t.Run("Other domain meeting is not found", func(t *testing.T) {
+		svc, mockStore := setupMeetingService(t)
+		token, err := svc.encodeToken("meeting")
+		require.NoError(t, err)
+
+		mockStore.On("Get", ctx, "meeting").Return(&model.Meeting{Id: "meeting", DomainId: 2}, nil)
+		mockStore.On("GetCalls", ctx, "meeting").Return(nil, nil)
+
+		_, err = svc.GetMeetingDetails(ctx, "access", 1, token, false)
+		require.ErrorIs(t, err, ErrMeetingNotFound)
+	})
+}
+
+func TestMeetingService_MeetingCallControls(t *testing.T) {
+	ctx := context.Background()
+	ended := int64(100)
+
+	tests := []struct {
+		name    string
+		meeting *model.Meeting
+		calls   []*model.MeetingCall
+		action  func(svc *MeetingService, token string) error
+		wantErr error
+	}{
+		{
+			name: "not found",
+			action: func(svc *MeetingService, token string) error {
+				_, err := svc.HoldMeetingCall(ctx, "access", 1, token)
+				return err
+			},
+			wantErr: ErrMeetingNotFound,
+		},
+		{
+			name:    "other domain",
+			meeting: &model.Meeting{Id: "meeting", DomainId: 2},
+			calls:   []*model.MeetingCall{{CallId: "call"}},
+			action: func(svc *MeetingService, token string) error {
+				_, err := svc.UnHoldMeetingCall(ctx, "access", 1, token)
+				return err
+			},
+			wantErr: ErrMeetingNotFound,
+		},
+		{
+			name:    "no active call",
+			meeting: &model.Meeting{Id: "meeting", DomainId: 1},
+			calls:   []*model.MeetingCall{{CallId: "call", EndedAt: &ended}},
+			action: func(svc *MeetingService, token string) error {
+				_, err := svc.HoldMeetingCall(ctx, "access", 1, token)
+				return err
+			},
+			wantErr: ErrMeetingClosed,
+		},
+		{
+			name: "empty transfer destination",
+			action: func(svc *MeetingService, token string) error {
+				_, err := svc.BlindTransferMeetingCall(ctx, "access", 1, token, " ")
+				return err
+			},
+			wantErr: ErrMeetingInvalid,
+		},
+		{
+			name: "invalid dtmf digit",
+			action: func(svc *MeetingService, token string) error {
+				_, err := svc.DtmfMeetingCall(ctx, "access", 1, token, "1x")
+				return err
+			},
+			wantErr: ErrMeetingInvalid,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, mockStore := setupMeetingService(t)
+			token, err := svc.encodeToken("meeting")
+			require.NoError(t, err)
+
+			mockStore.On("Get", ctx, "meeting").Return(tt.meeting, nil)
+			mockStore.On("GetCalls", ctx, "meeting").Return(tt.calls, nil)
+
+			require.ErrorIs(t, tt.action(svc, token), tt.wantErr)
+			mockStore.AssertNotCalled(t, "SetState", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestMeetingService_CreateMeetingQuota(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		quota   *model.MeetingQuota
+		active  int
+		today   int
+		created bool
+		wantErr error
+	}{
+		{name: "unlimited", quota: &model.MeetingQuota{}, created: true},
+		{name: "active limit", quota: &model.MeetingQuota{MaxActive: 2}, active: 2, wantErr: ErrMeetingQuota},
+		{name: "daily limit", quota: &model.MeetingQuota{MaxActive: 2, MaxDaily: 5}, active: 1, today: 5, wantErr: ErrMeetingQuota},
+		{name: "taken meanwhile", quota: &model.MeetingQuota{MaxActive: 2, MaxDaily: 5}, active: 1, today: 4, wantErr: ErrMeetingQuota},
+		{name: "within limits", quota: &model.MeetingQuota{MaxActive: 2, MaxDaily: 5}, active: 1, today: 4, created: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, mockStore := setupMeetingService(t)
+			svc.quota = tt.quota
+
+			mockStore.On("CountActive", ctx, int64(1), mock.AnythingOfType("int64")).Return(tt.active, nil)
+			mockStore.On("DailyUsage", ctx, int64(1), mock.AnythingOfType("int64")).Return(tt.today, nil)
+			mockStore.On("Create", ctx, mock.Anything, *tt.quota).Return(tt.created, nil)
+
+			_, _, err := svc.CreateMeeting(ctx, 1, "Quota", 3600, "http://base", nil, nil)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+// licensedDomains grants the product licenses to the domains.
+type licensedDomains struct {
+	auth.Manager
+
+	licenses map[int64]string
+}
+
+func (m licensedDomains) DomainLicense(_ context.Context, domainId int64, productName string) (bool, error) {
+	return m.licenses[domainId] == productName, nil
+}
+
+func TestMeetingService_CheckLicense(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := setupMeetingService(t)
+	assert.NoError(t, svc.CheckLicense(ctx, nil, 1))
+
+	svc.quota = &model.MeetingQuota{License: auth.LicenseChat}
+	assert.ErrorIs(t, svc.CheckLicense(ctx, &auth.Session{}, 0), ErrMeetingLicense)
+	assert.ErrorIs(t, svc.CheckLicense(ctx, auth.NewSession(2, 10), 1), ErrMeetingLicense)
+
+	// the internal caller with the service token only: the license of the request domain is checked
+	svc.auth = licensedDomains{licenses: map[int64]string{1: auth.LicenseChat, 2: auth.LicenseEmail}}
+	assert.NoError(t, svc.CheckLicense(ctx, nil, 1))
+	assert.ErrorIs(t, svc.CheckLicense(ctx, nil, 2), ErrMeetingLicense)
+	assert.ErrorIs(t, svc.CheckLicense(ctx, nil, 3), ErrMeetingLicense)
+}
+
+func TestMeetingService_CreateMeetingChatRequiresConnection(t *testing.T) {
+	svc, mockStore := setupMeetingService(t)
+
+	_, _, err := svc.CreateMeeting(context.Background(), 1, "Chat", 3600, "http://base", nil, &model.MeetingChat{})
+	require.ErrorIs(t, err, ErrMeetingInvalid)
+	mockStore.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestMeetingService_SendMeetingMessage(t *testing.T) {
+	ctx := context.Background()
+	conversationId, channelId := "conversation", "channel"
+
+	tests := []struct {
+		name    string
+		meeting *model.Meeting
+		guest   bool
+		text    string
+		wantErr error
+	}{
+		{name: "empty text", text: " ", wantErr: ErrMeetingInvalid},
+		{name: "not found", text: "hello", wantErr: ErrMeetingNotFound},
+		{
+			name:    "no chat",
+			meeting: &model.Meeting{Id: "meeting", ExpiresAt: time.Now().Unix() + 60},
+			text:    "hello",
+			wantErr: ErrMeetingNoChat,
+		},
+		{
+			name: "no guest credential",
+			meeting: &model.Meeting{Id: "meeting", ExpiresAt: time.Now().Unix() + 60,
+				ConversationId: &conversationId, ChatChannelId: &channelId},
+			text:    "hello",
+			wantErr: ErrGuestInvalid,
+		},
+		{
+			name: "ended",
+			meeting: &model.Meeting{Id: "meeting", ExpiresAt: time.Now().Unix() + 60, State: model.MeetingStateEnded,
+				ConversationId: &conversationId, ChatChannelId: &channelId},
+			guest:   true,
+			text:    "hello",
+			wantErr: ErrMeetingClosed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, mockStore := setupMeetingService(t)
+			token, err := svc.encodeToken("meeting")
+			require.NoError(t, err)
+
+			mockStore.On("Get", ctx, "meeting").Return(tt.meeting, nil)
+			mockStore.On("GetCalls", ctx, "meeting").Return(nil, nil)
+			mockStore.On("GetParticipant", ctx, "guest").Return(&model.Participant{Id: "guest", MeetingId: "meeting"}, nil)
+
+			var guestToken string
+			if tt.guest {
+				guestToken, _ = svc.guests.Issue("meeting", "guest", time.Now())
+			}
+
+			_, err = svc.SendMeetingMessage(ctx, token, guestToken, tt.text)
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestMeetingService_JoinMeeting(t *testing.T) {
+	ctx := context.Background()
+	revokedAt := time.Now().Unix()
+
+	tests := []struct {
+		name        string
+		meeting     *model.Meeting
+		participant *model.Participant
+		guest       bool
+		token       string
+		wantNew     bool
+		wantErr     error
+	}{
+		{name: "not found", wantErr: ErrMeetingNotFound},
+		{
+			name:    "ended",
+			meeting: &model.Meeting{Id: "meeting", ExpiresAt: time.Now().Unix() + 60, State: model.MeetingStateEnded},
+			wantErr: ErrMeetingClosed,
+		},
+		{
+			name:    "new participant",
+			meeting: &model.Meeting{Id: "meeting", ExpiresAt: time.Now().Unix() + 60},
+			wantNew: true,
+		},
+		{
+			name:        "renew",
+			meeting:     &model.Meeting{Id: "meeting", ExpiresAt: time.Now().Unix() + 60},
+			participant: &model.Participant{Id: "guest", MeetingId: "meeting"},
+			guest:       true,
+		},
+		{
+			name:        "revoked",
+			meeting:     &model.Meeting{Id: "meeting", ExpiresAt: time.Now().Unix() + 60},
+			participant: &model.Participant{Id: "guest", MeetingId: "meeting", RevokedAt: &revokedAt},
+			guest:       true,
+			wantErr:     ErrGuestInvalid,
+		},
+		{
+			name:    "forged",
+			meeting: &model.Meeting{Id: "meeting", ExpiresAt: time.Now().Unix() + 60},
+			token:   "guest.9999999999.forged",
+			wantErr: ErrGuestInvalid,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, mockStore := setupMeetingService(t)
+			token, err := svc.encodeToken("meeting")
+			require.NoError(t, err)
+
+			mockStore.On("Get", ctx, "meeting").Return(tt.meeting, nil)
+			mockStore.On("GetCalls", ctx, "meeting").Return(nil, nil)
+			mockStore.On("GetParticipant", ctx, "guest").Return(tt.participant, nil)
+			mockStore.On("CreateParticipant", ctx, mock.AnythingOfType("*model.Participant")).Return(nil)
+
+			guestToken := tt.token
+			if tt.guest {
+				guestToken, _ = svc.guests.Issue("meeting", "guest", time.Now())
+			}
+
+			c, err := svc.JoinMeeting(ctx, token, guestToken)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				mockStore.AssertNotCalled(t, "CreateParticipant", mock.Anything, mock.Anything)
+				return
+			}
+
+			require.NoError(t, err)
+
+			id, err := svc.guests.Verify(c.Token, "meeting", time.Now())
+			require.NoError(t, err)
+			assert.Equal(t, c.ParticipantId, id)
+
+			if tt.wantNew {
+				assert.NotEqual(t, "guest", c.ParticipantId)
+				mockStore.AssertCalled(t, "CreateParticipant", ctx, mock.Anything)
+			} else {
+				assert.Equal(t, "guest", c.ParticipantId)
+				mockStore.AssertNotCalled(t, "CreateParticipant", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestMeetingService_RevokeMeetingParticipant(t *testing.T) {
+	ctx := context.Background()
+	svc, mockStore := setupMeetingService(t)
+	token, err := svc.encodeToken("meeting")
+	require.NoError(t, err)
+
+	mockStore.On("Get", ctx, "meeting").Return(&model.Meeting{Id: "meeting", DomainId: 1}, nil)
+	mockStore.On("GetCalls", ctx, "meeting").Return(nil, nil)
+	mockStore.On("RevokeParticipant", ctx, "meeting", "guest", mock.AnythingOfType("int64")).Return(true, nil)
+	mockStore.On("RevokeParticipant", ctx, "meeting", "unknown", mock.AnythingOfType("int64")).Return(false, nil)
+
+	require.NoError(t, svc.RevokeMeetingParticipant(ctx, 1, token, "guest"))
+	require.ErrorIs(t, svc.RevokeMeetingParticipant(ctx, 1, token, "unknown"), ErrMeetingNotFound)
+	require.ErrorIs(t, svc.RevokeMeetingParticipant(ctx, 2, token, "guest"), ErrMeetingNotFound)
+}
+
+func TestMeetingService_OpenMeeting(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Meeting without chat is not marked opened", func(t *testing.T) {
+		svc, mockStore := setupMeetingService(t)
+		token, err := svc.encodeToken("meeting")
+		require.NoError(t, err)
+
+		mockStore.On("Get", ctx, "meeting").Return(&model.Meeting{Id: "meeting", DomainId: 1}, nil)
+		mockStore.On("GetCalls", ctx, "meeting").Return(nil, nil)
+
+		meeting, err := svc.OpenMeeting(ctx, token)
+		require.NoError(t, err)
+		assert.Equal(t, "meeting", meeting.Id)
+		mockStore.AssertNotCalled(t, "SetOpened", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Repeated opening posts nothing", func(t *testing.T) {
+		svc, mockStore := setupMeetingService(t)
+		token, err := svc.encodeToken("meeting")
+		require.NoError(t, err)
+
+		conversationId := "conversation"
+		mockStore.On("Get", ctx, "meeting").Return(&model.Meeting{Id: "meeting", DomainId: 1, ConversationId: &conversationId}, nil)
+		mockStore.On("GetCalls", ctx, "meeting").Return(nil, nil)
+		mockStore.On("SetOpened", ctx, "meeting", mock.AnythingOfType("int64")).Return(false, nil)
+
+		_, err = svc.OpenMeeting(ctx, token)
+		require.NoError(t, err)
+		mockStore.AssertExpectations(t)
+	})
+}
+
+func TestMeetingService_MeetingTranscript(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		meeting *model.Meeting
+		wantErr error
+	}{
+		{name: "not found", wantErr: ErrMeetingNotFound},
+		{name: "other domain", meeting: &model.Meeting{Id: "meeting", DomainId: 2}, wantErr: ErrMeetingNotFound},
+		{name: "no chat", meeting: &model.Meeting{Id: "meeting", DomainId: 1}, wantErr: ErrMeetingNoChat},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, mockStore := setupMeetingService(t)
+			token, err := svc.encodeToken("meeting")
+			require.NoError(t, err)
+
+			mockStore.On("Get", ctx, "meeting").Return(tt.meeting, nil)
+			mockStore.On("GetCalls", ctx, "meeting").Return(nil, nil)
+
+			_, _, _, err = svc.MeetingTranscript(ctx, 1, token, 1, 20)
+			require.ErrorIs(t, err, tt.wantErr)
+
+			_, err = svc.ExportMeetingTranscript(ctx, 1, token, model.TranscriptFormatText)
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestMeetingService_LinkMeetingContact(t *testing.T) {
+	ctx := context.Background()
+	conversationId, contactId := "conversation", "42"
+
+	tests := []struct {
+		name      string
+		meeting   *model.Meeting
+		contactId string
+		want      string
+		wantErr   error
+	}{
+		{name: "not found", wantErr: ErrMeetingNotFound},
+		{name: "no chat", meeting: &model.Meeting{Id: "meeting", DomainId: 1}, wantErr: ErrMeetingNoChat},
+		{
+			name:      "invalid contact id",
+			meeting:   &model.Meeting{Id: "meeting", DomainId: 1, ConversationId: &conversationId},
+			contactId: "olena",
+			wantErr:   ErrMeetingInvalid,
+		},
+		{
+			name:    "already created",
+			meeting: &model.Meeting{Id: "meeting", DomainId: 1, ConversationId: &conversationId, ContactId: &contactId},
+			want:    contactId,
+		},
+		{
+			name:      "already linked",
+			meeting:   &model.Meeting{Id: "meeting", DomainId: 1, ConversationId: &conversationId, ContactId: &contactId},
+			contactId: contactId,
+			want:      contactId,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, mockStore := setupMeetingService(t)
+			token, err := svc.encodeToken("meeting")
+			require.NoError(t, err)
+
+			mockStore.On("Get", ctx, "meeting").Return(tt.meeting, nil)
+			mockStore.On("GetCalls", ctx, "meeting").Return(nil, nil)
+
+			got, err := svc.LinkMeetingContact(ctx, "access", 1, token, tt.contactId)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+
+			mockStore.AssertNotCalled(t, "SetContact", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestMeetingService_ChatPolicy(t *testing.T) {
+	ctx := context.Background()
+	parentId := "parent"
+	conversationId := "conversation"
+
+	legHangup := func(svc *MeetingService, mockStore *MockMeetingStore) error {
+		mockStore.On("FindByCall", ctx, parentId).Return("meeting", nil)
+		mockStore.On("ClaimCallEvent", ctx, "meeting", "leg", model.CallEventHangup, int64(700000)).Return(true, nil)
+		mockStore.On("CompleteCallEvent", ctx, "leg", model.CallEventHangup, int64(700000)).Return(nil)
+		mockStore.On("EndCall", ctx, "meeting", mock.AnythingOfType("*model.MeetingCall")).Return(nil)
+		mockStore.On("GetCalls", ctx, "meeting").Return([]*model.MeetingCall{{CallId: parentId}}, nil)
+		mockStore.On("SetCall", ctx, "meeting", "leg", false, int64(700000)).Return(true, nil)
+		mockStore.On("Get", ctx, "meeting").Return(&model.Meeting{Id: "meeting", DomainId: 1, ConversationId: &conversationId}, nil)
+
+		_, err := svc.ProcessCall(ctx, &model.Call{
+			Id:        "leg",
+			Event:     model.CallEventHangup,
+			Timestamp: 700,
+			Data:      model.CallHangupData{ParentId: &parentId},
+		})
+		return err
+	}
+
+	t.Run("Delayed close is scheduled", func(t *testing.T) {
+		svc, mockStore := setupMeetingService(t)
+		mockStore.On("GetChatPolicy", ctx, int64(1)).Return(&model.ChatPolicy{Mode: model.ChatCloseAlways, Cause: "flow_end", DelaySec: 30}, nil)
+		mockStore.On("ScheduleChatClose", ctx, "meeting", mock.AnythingOfType("int64")).Return(nil)
+
+		require.NoError(t, legHangup(svc, mockStore))
+		mockStore.AssertExpectations(t)
+	})
+
+	t.Run("Not bridged call keeps the chat", func(t *testing.T) {
+		svc, mockStore := setupMeetingService(t)
+		mockStore.On("GetChatPolicy", ctx, int64(1)).Return(&model.ChatPolicy{Mode: model.ChatCloseBridged, Cause: "no_cause"}, nil)
+
+		require.NoError(t, legHangup(svc, mockStore))
+		mockStore.AssertNotCalled(t, "ScheduleChatClose", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Invalid policy is rejected", func(t *testing.T) {
+		svc, mockStore := setupMeetingService(t)
+
+		_, err := svc.UpdateChatPolicy(ctx, &model.ChatPolicy{DomainId: 1, Mode: model.ChatCloseTransfer})
+		require.ErrorIs(t, err, ErrMeetingInvalid)
+		mockStore.AssertNotCalled(t, "SetChatPolicy", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Default policy", func(t *testing.T) {
+		svc, mockStore := setupMeetingService(t)
+		mockStore.On("GetChatPolicy", ctx, int64(1)).Return(nil, nil)
+
+		p, err := svc.GetChatPolicy(ctx, 1)
+		require.NoError(t, err)
+		assert.Equal(t, model.DefaultChatPolicy(1), p)
+	})
+}
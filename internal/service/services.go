@@ -7,5 +7,6 @@ var Module = fx.Module("service",
 		NewMeetingService,
 		NewChatService,
 		NewCallService,
+		NewWebhookService,
 	),
 )
@@ -0,0 +1,432 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jpillora/backoff"
+	"go.uber.org/fx"
+
+	"github.com/webitel/wlog"
+
+	"github.com/webitel/web-meeting-backend/config"
+	"github.com/webitel/web-meeting-backend/infra/encrypter"
+	"github.com/webitel/web-meeting-backend/infra/webhook"
+	"github.com/webitel/web-meeting-backend/internal/model"
+)
+
+const (
+	defaultWebhookMaxAttempts      = 5
+	defaultWebhookFailureThreshold = 10
+)
+
+var (
+	ErrWebhookNotFound = errors.New("webhook not found")
+	ErrWebhookInvalid  = errors.New("invalid webhook")
+)
+
+type WebhookStore interface {
+	Create(ctx context.Context, w *model.Webhook) error
+	Get(ctx context.Context, domainId, id int64) (*model.Webhook, error)
+	List(ctx context.Context, domainId int64, page, size int) ([]*model.Webhook, error)
+	ListSubscribed(ctx context.Context, domainId int64, event string) ([]*model.Webhook, error)
+	Update(ctx context.Context, w *model.Webhook) error
+	Delete(ctx context.Context, domainId, id int64) error
+
+	LogDelivery(ctx context.Context, d *model.WebhookDelivery) error
+	ListDeliveries(ctx context.Context, domainId, webhookId int64, page, size int) ([]*model.WebhookDelivery, error)
+	RegisterFailure(ctx context.Context, id int64, threshold int, now int64) (bool, error)
+	ResetFailures(ctx context.Context, id int64) error
+}
+
+type WebhookSender interface {
+	Send(ctx context.Context, r *webhook.Request) (int, error)
+	// CheckURL rejects the receiver URL the sender would refuse to post to.
+	CheckURL(rawURL string) error
+}
+
+type WebhookService struct {
+	log       *wlog.Logger
+	store     WebhookStore
+	sender    WebhookSender
+	encrypter *encrypter.DataEncrypter
+
+	maxAttempts      int
+	failureThreshold int
+	backoff          func() *backoff.Backoff
+
+	mu      sync.Mutex
+	stopped bool
+	stop    chan struct{}
+	wg      sync.WaitGroup
+}
+
+func NewWebhookService(cfg *config.Config, log *wlog.Logger, st WebhookStore, enc *encrypter.DataEncrypter, lc fx.Lifecycle) *WebhookService {
+	s := newWebhookService(log, st, webhook.NewClient(cfg.Webhook.Timeout, cfg.Webhook.AllowPrivate), enc, cfg.Webhook.MaxAttempts, cfg.Webhook.FailureThreshold)
+
+	lc.Append(fx.Hook{
+		OnStop: func(ctx context.Context) error {
+			s.Stop()
+			return nil
+		},
+	})
+
+	return s
+}
+
+func newWebhookService(log *wlog.Logger, st WebhookStore, sender WebhookSender, enc *encrypter.DataEncrypter,
+	maxAttempts, failureThreshold int,
+) *WebhookService {
+	if maxAttempts < 1 {
+		maxAttempts = defaultWebhookMaxAttempts
+	}
+
+	if failureThreshold < 1 {
+		failureThreshold = defaultWebhookFailureThreshold
+	}
+
+	return &WebhookService{
+		log:              log.With(wlog.String("scope", "webhook")),
+		store:            st,
+		sender:           sender,
+		encrypter:        enc,
+		maxAttempts:      maxAttempts,
+		failureThreshold: failureThreshold,
+		backoff: func() *backoff.Backoff {
+			return &backoff.Backoff{
+				Min:    time.Second,
+				Max:    time.Minute,
+				Factor: 2,
+				Jitter: true,
+			}
+		},
+		stop: make(chan struct{}),
+	}
+}
+
+// Stop interrupts pending retries and waits for in-flight deliveries, the events dispatched later are dropped.
+func (s *WebhookService) Stop() {
+	s.mu.Lock()
+	if !s.stopped {
+		s.stopped = true
+		close(s.stop)
+	}
+	s.mu.Unlock()
+
+	s.wg.Wait()
+}
+
+// start registers the delivery unless the service is stopped, so Stop waits for every started delivery.
+func (s *WebhookService) start() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.stopped {
+		return false
+	}
+
+	s.wg.Add(1)
+	return true
+}
+
+func (s *WebhookService) Create(ctx context.Context, w *model.Webhook) (string, error) {
+	if err := s.validateWebhook(w); err != nil {
+		return "", err
+	}
+
+	secret := w.Secret
+	if secret == "" {
+		b := make([]byte, 32)
+		if _, err := rand.Read(b); err != nil {
+			return "", err
+		}
+		secret = hex.EncodeToString(b)
+	}
+
+	var err error
+	if w.Secret, err = s.encryptSecret(secret); err != nil {
+		return "", err
+	}
+
+	now := time.Now().Unix()
+	w.Enabled = true
+	w.CreatedAt = now
+	w.UpdatedAt = now
+
+	if err = s.store.Create(ctx, w); err != nil {
+		return "", err
+	}
+
+	return secret, nil
+}
+
+func (s *WebhookService) Get(ctx context.Context, domainId, id int64) (*model.Webhook, error) {
+	w, err := s.store.Get(ctx, domainId, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if w == nil {
+		return nil, ErrWebhookNotFound
+	}
+
+	return w, nil
+}
+
+func (s *WebhookService) List(ctx context.Context, domainId int64, page, size int) ([]*model.Webhook, bool, error) {
+	page, size = normalizePage(page, size)
+
+	list, err := s.store.List(ctx, domainId, page, size)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if len(list) > size {
+		return list[:size], true, nil
+	}
+
+	return list, false, nil
+}
+
+// Update replaces the webhook, the current secret is kept when the new one is empty
+// and the current enabled flag when enabled is nil.
+func (s *WebhookService) Update(ctx context.Context, w *model.Webhook, enabled *bool) (*model.Webhook, error) {
+	if err := s.validateWebhook(w); err != nil {
+		return nil, err
+	}
+
+	current, err := s.Get(ctx, w.DomainId, w.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if w.Secret == "" {
+		w.Secret = current.Secret
+	} else if w.Secret, err = s.encryptSecret(w.Secret); err != nil {
+		return nil, err
+	}
+
+	w.Enabled = current.Enabled
+	if enabled != nil {
+		w.Enabled = *enabled
+	}
+
+	w.UpdatedAt = time.Now().Unix()
+	if err = s.store.Update(ctx, w); err != nil {
+		return nil, err
+	}
+
+	return s.Get(ctx, w.DomainId, w.Id)
+}
+
+func (s *WebhookService) Delete(ctx context.Context, domainId, id int64) error {
+	if _, err := s.Get(ctx, domainId, id); err != nil {
+		return err
+	}
+
+	return s.store.Delete(ctx, domainId, id)
+}
+
+func (s *WebhookService) ListDeliveries(ctx context.Context, domainId, webhookId int64, page, size int) ([]*model.WebhookDelivery, bool, error) {
+	if _, err := s.Get(ctx, domainId, webhookId); err != nil {
+		return nil, false, err
+	}
+
+	page, size = normalizePage(page, size)
+
+	list, err := s.store.ListDeliveries(ctx, domainId, webhookId, page, size)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if len(list) > size {
+		return list[:size], true, nil
+	}
+
+	return list, false, nil
+}
+
+// Dispatch delivers the event to every enabled webhook of the domain subscribed to it.
+// Deliveries run in background, so a slow receiver never blocks the meeting flow.
+func (s *WebhookService) Dispatch(ctx context.Context, ev *model.MeetingEvent) {
+	select {
+	case <-s.stop:
+		s.log.Warn("webhook service is stopped, dropping event", wlog.String("event", ev.Type),
+			wlog.Int64("domain_id", ev.DomainId))
+		return
+	default:
+	}
+
+	if ev.Id == "" {
+		ev.Id = uuid.NewString()
+	}
+
+	if ev.CreatedAt == 0 {
+		ev.CreatedAt = time.Now().UnixMilli()
+	}
+
+	hooks, err := s.store.ListSubscribed(ctx, ev.DomainId, ev.Type)
+	if err != nil {
+		s.log.Error("failed to list webhooks", wlog.Err(err), wlog.Int64("domain_id", ev.DomainId))
+		return
+	}
+
+	if len(hooks) == 0 {
+		return
+	}
+
+	body, err := json.Marshal(ev)
+	if err != nil {
+		s.log.Error("failed to marshal event", wlog.Err(err))
+		return
+	}
+
+	for _, w := range hooks {
+		if !s.start() {
+			s.log.Warn("webhook service is stopped, dropping event", wlog.String("event_id", ev.Id))
+			return
+		}
+
+		go func() {
+			defer s.wg.Done()
+			s.deliver(w, ev, body)
+		}()
+	}
+}
+
+func (s *WebhookService) deliver(w *model.Webhook, ev *model.MeetingEvent, body []byte) {
+	ctx := context.Background()
+	log := s.log.With(wlog.Int64("webhook_id", w.Id), wlog.String("event_id", ev.Id))
+
+	secret, err := s.decryptSecret(w.Secret)
+	if err != nil {
+		log.Error("failed to decrypt webhook secret", wlog.Err(err))
+		return
+	}
+
+	b := s.backoff()
+
+	for attempt := 1; attempt <= s.maxAttempts; attempt++ {
+		start := time.Now()
+		code, sendErr := s.sender.Send(ctx, &webhook.Request{
+			Url:        w.Url,
+			Secret:     secret,
+			Event:      ev.Type,
+			DeliveryId: ev.Id,
+			Body:       body,
+		})
+
+		d := &model.WebhookDelivery{
+			WebhookId:  w.Id,
+			EventId:    ev.Id,
+			Event:      ev.Type,
+			Attempt:    int32(attempt),
+			StatusCode: int32(code),
+			DurationMs: time.Since(start).Milliseconds(),
+			Success:    sendErr == nil,
+			CreatedAt:  start.Unix(),
+		}
+		if sendErr != nil {
+			msg := sendErr.Error()
+			d.Error = &msg
+		}
+
+		if err = s.store.LogDelivery(ctx, d); err != nil {
+			log.Error("failed to log webhook delivery", wlog.Err(err))
+		}
+
+		if sendErr == nil {
+			if w.Failures > 0 {
+				if err = s.store.ResetFailures(ctx, w.Id); err != nil {
+					log.Error("failed to reset webhook failures", wlog.Err(err))
+				}
+			}
+
+			return
+		}
+
+		log.Warn(fmt.Sprintf("webhook delivery attempt %d/%d failed", attempt, s.maxAttempts), wlog.Err(sendErr))
+
+		if attempt == s.maxAttempts {
+			break
+		}
+
+		select {
+		case <-time.After(b.Duration()):
+		case <-s.stop:
+			log.Warn("webhook delivery interrupted by shutdown")
+			return
+		}
+	}
+
+	disabled, err := s.store.RegisterFailure(ctx, w.Id, s.failureThreshold, time.Now().Unix())
+	if err != nil {
+		log.Error("failed to register webhook failure", wlog.Err(err))
+		return
+	}
+
+	if disabled {
+		log.Warn("webhook disabled after repeated failures")
+	}
+}
+
+func (s *WebhookService) encryptSecret(secret string) (string, error) {
+	enc, err := s.encrypter.Encrypt([]byte(secret))
+	if err != nil {
+		return "", fmt.Errorf("failed to encrypt webhook secret: %w", err)
+	}
+
+	return base64.StdEncoding.EncodeToString(enc), nil
+}
+
+func (s *WebhookService) decryptSecret(secret string) (string, error) {
+	enc, err := base64.StdEncoding.DecodeString(secret)
+	if err != nil {
+		return "", err
+	}
+
+	plain, err := s.encrypter.Decrypt(enc)
+	if err != nil {
+		return "", err
+	}
+
+	return string(plain), nil
+}
+
+func (s *WebhookService) validateWebhook(w *model.Webhook) error {
+	if err := s.sender.CheckURL(w.Url); err != nil {
+		return fmt.Errorf("%w: %w", ErrWebhookInvalid, err)
+	}
+
+	if len(w.Events) == 0 {
+		return fmt.Errorf("%w: events is required", ErrWebhookInvalid)
+	}
+
+	for _, e := range w.Events {
+		if !model.IsMeetingEventType(e) {
+			return fmt.Errorf("%w: unknown event type %q", ErrWebhookInvalid, e)
+		}
+	}
+
+	return nil
+}
+
+func normalizePage(page, size int) (int, int) {
+	if page < 1 {
+		page = 1
+	}
+
+	if size < 1 || size > 100 {
+		size = 20
+	}
+
+	return page, size
+}
@@ -0,0 +1,244 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jpillora/backoff"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/webitel/wlog"
+
+	"github.com/webitel/web-meeting-backend/infra/encrypter"
+	"github.com/webitel/web-meeting-backend/infra/webhook"
+	"github.com/webitel/web-meeting-backend/internal/model"
+)
+
+// memoryWebhookStore keeps webhooks in memory for delivery tests.
+type memoryWebhookStore struct {
+	mu         sync.Mutex
+	hooks      map[int64]*model.Webhook
+	deliveries []*model.WebhookDelivery
+}
+
+func newMemoryWebhookStore() *memoryWebhookStore {
+	return &memoryWebhookStore{hooks: make(map[int64]*model.Webhook)}
+}
+
+func (m *memoryWebhookStore) Create(_ context.Context, w *model.Webhook) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	w.Id = int64(len(m.hooks) + 1)
+	c := *w
+	m.hooks[w.Id] = &c
+
+	return nil
+}
+
+func (m *memoryWebhookStore) Get(_ context.Context, domainId, id int64) (*model.Webhook, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if w, ok := m.hooks[id]; ok && w.DomainId == domainId {
+		c := *w
+		return &c, nil
+	}
+
+	return nil, nil
+}
+
+func (m *memoryWebhookStore) List(context.Context, int64, int, int) ([]*model.Webhook, error) {
+	return nil, nil
+}
+
+func (m *memoryWebhookStore) ListSubscribed(_ context.Context, domainId int64, event string) ([]*model.Webhook, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var res []*model.Webhook
+	for _, w := range m.hooks {
+		if w.DomainId == domainId && w.Enabled && w.Subscribed(event) {
+			c := *w
+			res = append(res, &c)
+		}
+	}
+
+	return res, nil
+}
+
+func (m *memoryWebhookStore) Update(context.Context, *model.Webhook) error { return nil }
+
+func (m *memoryWebhookStore) Delete(context.Context, int64, int64) error { return nil }
+
+func (m *memoryWebhookStore) LogDelivery(_ context.Context, d *model.WebhookDelivery) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.deliveries = append(m.deliveries, d)
+
+	return nil
+}
+
+func (m *memoryWebhookStore) ListDeliveries(context.Context, int64, int64, int, int) ([]*model.WebhookDelivery, error) {
+	return nil, nil
+}
+
+func (m *memoryWebhookStore) RegisterFailure(_ context.Context, id int64, threshold int, now int64) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	w := m.hooks[id]
+	w.Failures++
+	if int(w.Failures) >= threshold {
+		w.Enabled = false
+		w.DisabledAt = &now
+	}
+
+	return !w.Enabled, nil
+}
+
+func (m *memoryWebhookStore) ResetFailures(_ context.Context, id int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.hooks[id].Failures = 0
+
+	return nil
+}
+
+func setupWebhookService(t *testing.T, st WebhookStore, maxAttempts, threshold int) *WebhookService {
+	t.Helper()
+
+	// the test receivers listen on the loopback
+	return setupWebhookServiceWith(t, st, webhook.NewClient(time.Second, true), maxAttempts, threshold)
+}
+
+func setupWebhookServiceWith(t *testing.T, st WebhookStore, sender WebhookSender, maxAttempts, threshold int) *WebhookService {
+	t.Helper()
+
+	enc, err := encrypter.New([]byte("12345678901234567890123456789012"))
+	require.NoError(t, err)
+
+	s := newWebhookService(wlog.NewLogger(&wlog.LoggerConfiguration{EnableConsole: false}), st,
+		sender, enc, maxAttempts, threshold)
+	s.backoff = func() *backoff.Backoff {
+		return &backoff.Backoff{Min: time.Millisecond, Max: time.Millisecond}
+	}
+
+	return s
+}
+
+func TestWebhookService_DispatchSigned(t *testing.T) {
+	st := newMemoryWebhookStore()
+	received := make(chan *http.Request, 1)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- r
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	s := setupWebhookService(t, st, 3, 3)
+	ctx := context.Background()
+
+	secret, err := s.Create(ctx, &model.Webhook{DomainId: 1, Url: srv.URL, Events: []string{model.EventMeetingCreated}})
+	require.NoError(t, err)
+	require.NotEmpty(t, secret)
+
+	s.Dispatch(ctx, &model.MeetingEvent{Type: model.EventMeetingDeleted, DomainId: 1, MeetingId: "t"})
+	s.Dispatch(ctx, &model.MeetingEvent{Type: model.EventMeetingCreated, DomainId: 2, MeetingId: "t"})
+	s.Dispatch(ctx, &model.MeetingEvent{Type: model.EventMeetingCreated, DomainId: 1, MeetingId: "t"})
+	s.Stop()
+
+	require.Len(t, received, 1)
+	r := <-received
+	assert.Equal(t, model.EventMeetingCreated, r.Header.Get(webhook.HeaderEvent))
+	assert.NotEmpty(t, r.Header.Get(webhook.HeaderSignature))
+
+	require.Len(t, st.deliveries, 1)
+	assert.True(t, st.deliveries[0].Success)
+}
+
+func TestWebhookService_DisableAfterFailures(t *testing.T) {
+	st := newMemoryWebhookStore()
+	var calls atomic.Int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	s := setupWebhookService(t, st, 3, 2)
+	ctx := context.Background()
+
+	_, err := s.Create(ctx, &model.Webhook{DomainId: 1, Url: srv.URL, Events: []string{model.EventMeetingCreated}})
+	require.NoError(t, err)
+
+	for range 2 {
+		s.Dispatch(ctx, &model.MeetingEvent{Type: model.EventMeetingCreated, DomainId: 1, MeetingId: "t"})
+		s.wg.Wait()
+	}
+
+	// a disabled webhook receives nothing
+	s.Dispatch(ctx, &model.MeetingEvent{Type: model.EventMeetingCreated, DomainId: 1, MeetingId: "t"})
+	s.Stop()
+
+	assert.Equal(t, int32(6), calls.Load())
+	assert.Len(t, st.deliveries, 6)
+
+	w, err := s.Get(ctx, 1, 1)
+	require.NoError(t, err)
+	assert.False(t, w.Enabled)
+	assert.NotNil(t, w.DisabledAt)
+	assert.Equal(t, http.StatusBadGateway, int(st.deliveries[0].StatusCode))
+}
+
+func TestWebhookService_CreateValidation(t *testing.T) {
+	s := setupWebhookService(t, newMemoryWebhookStore(), 1, 1)
+
+	_, err := s.Create(context.Background(), &model.Webhook{DomainId: 1, Url: "http://localhost", Events: []string{"unknown"}})
+	require.ErrorIs(t, err, ErrWebhookInvalid)
+
+	_, err = s.Create(context.Background(), &model.Webhook{DomainId: 1, Url: "http://localhost"})
+	require.ErrorIs(t, err, ErrWebhookInvalid)
+}
+
+func TestWebhookService_CreatePrivateAddress(t *testing.T) {
+	s := setupWebhookServiceWith(t, newMemoryWebhookStore(), webhook.NewClient(time.Second, false), 1, 1)
+	events := []string{model.EventMeetingCreated}
+
+	for _, u := range []string{"http://localhost:8080/hook", "http://127.0.0.1/hook", "http://10.0.0.5/hook", "http://169.254.169.254/"} {
+		_, err := s.Create(context.Background(), &model.Webhook{DomainId: 1, Url: u, Events: events})
+		require.ErrorIs(t, err, ErrWebhookInvalid, u)
+		require.ErrorIs(t, err, webhook.ErrAddressDenied, u)
+	}
+
+	_, err := s.Create(context.Background(), &model.Webhook{DomainId: 1, Url: "https://example.com/hook", Events: events})
+	require.NoError(t, err)
+}
+
+func TestWebhookService_DispatchAfterStop(t *testing.T) {
+	st := newMemoryWebhookStore()
+	var calls atomic.Int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	s := setupWebhookService(t, st, 1, 1)
+	ctx := context.Background()
+
+	_, err := s.Create(ctx, &model.Webhook{DomainId: 1, Url: srv.URL, Events: []string{model.EventMeetingCreated}})
+	require.NoError(t, err)
+
+	s.Stop()
+	s.Dispatch(ctx, &model.MeetingEvent{Type: model.EventMeetingCreated, DomainId: 1, MeetingId: "t"})
+	s.Stop()
+
+	assert.Zero(t, calls.Load())
+	assert.Empty(t, st.deliveries)
+}
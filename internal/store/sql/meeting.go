@@ -12,6 +12,14 @@ import (
 	"github.com/webitel/wlog"
 )
 
+// callInboxRetention keeps the processed call events for the broker redeliveries and the dead-letter replays.
+const callInboxRetention = 7 * 24 * time.Hour
+
+// callClaimLease is how long the claimed call event waits to be processed; the claim of the process
+// which died while processing the event expires, and the redelivery claims it again. It is shorter
+// than the retries of the event, so the redelivery isn't dead-lettered while the claim is held.
+const callClaimLease = 30 * time.Second
+
 type MeetingStoreImpl struct {
 	log *wlog.Logger
 	db  sql.Store
@@ -22,36 +30,68 @@ func NewMeetingStore(ctx context.Context, db sql.Store, log *wlog.Logger) *Meeti
 		log: log,
 		db:  db,
 	}
-	//go ms.cleanup(ctx) // TODO
+	go ms.cleanup(ctx)
 	return ms
 }
 
-func (s *MeetingStoreImpl) Create(ctx context.Context, m *model.Meeting) error {
-	err := s.db.Exec(ctx, `
-		INSERT INTO meetings.web_meetings (id, domain_id, title, created_at, expires_at, variables, url)
-		VALUES (@id, @domain_id, @title, @created_at, @expires_at, @variables, @url)
-	`, pgx.NamedArgs{
-		"id":         m.Id,
-		"domain_id":  m.DomainId,
-		"title":      m.Title,
-		"created_at": m.CreatedAt,
-		"expires_at": m.ExpiresAt,
-		"variables":  m.Variables,
-		"url":        m.Url,
-	})
+// Create stores the meeting and counts it in the daily usage of the domain in one statement unless
+// a limit of the quota is reached; reports whether it is created.
+func (s *MeetingStoreImpl) Create(ctx context.Context, m *model.Meeting, q model.MeetingQuota) (bool, error) {
+	var created bool
 
+	err := s.db.Get(ctx, &created, `with active as (
+    select count(*) as n
+    from meetings.web_meetings
+    where domain_id = @domain_id::bigint
+        and ended_at isnull
+        and expires_at > @created_at::bigint
+), usage as (
+    insert into meetings.daily_usage as u (domain_id, day, created)
+    select @domain_id::bigint, @day::bigint, 1
+    from active
+    where @max_active::int = 0 or active.n < @max_active::int
+    on conflict (domain_id, day) do update
+    set created = u.created + 1
+    where @max_daily::int = 0 or u.created < @max_daily::int
+    returning u.domain_id
+)
+insert into meetings.web_meetings (id, domain_id, title, created_at, expires_at, variables, url,
+                                   conversation_id, chat_channel_id)
+select @id::text, @domain_id::bigint, @title::text, @created_at::bigint, @expires_at::bigint, @variables::jsonb,
+       @url::text, @conversation_id::text, @chat_channel_id::text
+from usage
+returning true`, pgx.NamedArgs{
+		"id":              m.Id,
+		"domain_id":       m.DomainId,
+		"title":           m.Title,
+		"created_at":      m.CreatedAt,
+		"expires_at":      m.ExpiresAt,
+		"variables":       m.Variables,
+		"url":             m.Url,
+		"conversation_id": m.ConversationId,
+		"chat_channel_id": m.ChatChannelId,
+		"day":             model.UsageDay(time.Unix(m.CreatedAt, 0)),
+		"max_active":      q.MaxActive,
+		"max_daily":       q.MaxDaily,
+	})
 	if err != nil {
-		return fmt.Errorf("failed to create meeting: %w", err)
+		if s.db.IsNotFoundErr(err) {
+			return false, nil
+		}
+
+		return false, fmt.Errorf("failed to create meeting: %w", err)
 	}
 
-	return nil
+	return true, nil
 }
 
 func (s *MeetingStoreImpl) Get(ctx context.Context, id string) (*model.Meeting, error) {
 	var m model.Meeting
 
 	err := s.db.Get(ctx, &m, `
-		SELECT id, domain_id, title, created_at, expires_at, variables, url, call_id, satisfaction, bridged
+		SELECT id, domain_id, title, created_at, expires_at, variables, url, call_id, satisfaction, bridged,
+		       state, state_at, ringing_at, answered_at, bridged_at, ended_at, outcome, conversation_id,
+		       chat_channel_id, contact_id
 		FROM meetings.web_meetings
 		WHERE id = @id
 	`, pgx.NamedArgs{"id": id})
@@ -82,60 +122,536 @@ func (s *MeetingStoreImpl) DeleteExpires(ctx context.Context, now int64) error {
 	return nil
 }
 
-func (s *MeetingStoreImpl) SetCall(ctx context.Context, id string, callId string, bridged bool) error {
-	err := s.db.Exec(ctx, `update meetings.web_meetings
+// SetCall stores the finished call leg. The leg is ignored when a newer leg hangup has been applied,
+// or when it would replace a bridged leg with a not bridged one; returns false in that case.
+func (s *MeetingStoreImpl) SetCall(ctx context.Context, id string, callId string, bridged bool, eventAt int64) (bool, error) {
+	var applied bool
+
+	err := s.db.Get(ctx, &applied, `update meetings.web_meetings
 set call_id = @call_id,
-    bridged = @bridged
+    bridged = @bridged,
+    call_event_at = @event_at
+where id = @id
+    and coalesce(call_event_at, 0) <= @event_at
+    and (@bridged or not coalesce(bridged, false) or call_id = @call_id)
+returning true`, pgx.NamedArgs{
+		"id":       id,
+		"call_id":  callId,
+		"bridged":  bridged,
+		"event_at": eventAt,
+	})
+
+	if err != nil {
+		if s.db.IsNotFoundErr(err) {
+			return false, nil
+		}
+
+		return false, fmt.Errorf("failed to set call_id: %w", err)
+	}
+
+	return true, nil
+}
+
+// SetOpened stores when the customer opened the meeting link, reports whether it is the first opening.
+func (s *MeetingStoreImpl) SetOpened(ctx context.Context, id string, at int64) (bool, error) {
+	var first bool
+
+	err := s.db.Get(ctx, &first, `update meetings.web_meetings
+set opened_at = @at
+where id = @id
+    and opened_at isnull
+returning true`, pgx.NamedArgs{
+		"id": id,
+		"at": at,
+	})
+
+	if err != nil {
+		if s.db.IsNotFoundErr(err) {
+			return false, nil
+		}
+
+		return false, fmt.Errorf("failed to set opened_at: %w", err)
+	}
+
+	return true, nil
+}
+
+// SetContact stores the contact linked to the meeting conversation.
+func (s *MeetingStoreImpl) SetContact(ctx context.Context, id, contactId string) error {
+	err := s.db.Exec(ctx, `update meetings.web_meetings
+set contact_id = @contact_id
 where id = @id`, pgx.NamedArgs{
-		"id":      id,
-		"call_id": callId,
-		"bridged": bridged,
+		"id":         id,
+		"contact_id": contactId,
 	})
+	if err != nil {
+		return fmt.Errorf("failed to set contact_id: %w", err)
+	}
 
+	return nil
+}
+
+// SetConversation links the conversation to the meeting which has none.
+func (s *MeetingStoreImpl) SetConversation(ctx context.Context, id, conversationId string) error {
+	err := s.db.Exec(ctx, `update meetings.web_meetings
+set conversation_id = @conversation_id
+where id = @id
+    and conversation_id isnull`, pgx.NamedArgs{
+		"id":              id,
+		"conversation_id": conversationId,
+	})
 	if err != nil {
-		return fmt.Errorf("failed to set call_id: %w", err)
+		return fmt.Errorf("failed to set meeting conversation: %w", err)
 	}
 
 	return nil
 }
 
-func (s *MeetingStoreImpl) SetSatisfaction(ctx context.Context, id string, satisfaction string) error {
+// CountActive returns the number of the domain meetings which are neither ended nor expired.
+func (s *MeetingStoreImpl) CountActive(ctx context.Context, domainId int64, now int64) (int, error) {
+	var res int
+
+	err := s.db.Get(ctx, &res, `select count(*)
+from meetings.web_meetings
+where domain_id = @domain_id
+    and ended_at isnull
+    and expires_at > @now`, pgx.NamedArgs{
+		"domain_id": domainId,
+		"now":       now,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count active meetings: %w", err)
+	}
+
+	return res, nil
+}
+
+// DailyUsage returns the number of the meetings created by the domain in the day.
+func (s *MeetingStoreImpl) DailyUsage(ctx context.Context, domainId int64, day int64) (int, error) {
+	var res int
+
+	err := s.db.Get(ctx, &res, `select coalesce((
+    select created
+    from meetings.daily_usage
+    where domain_id = @domain_id
+        and day = @day
+), 0)`, pgx.NamedArgs{
+		"domain_id": domainId,
+		"day":       day,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to get daily usage: %w", err)
+	}
+
+	return res, nil
+}
+
+// CreateParticipant stores the guest who joined the meeting.
+func (s *MeetingStoreImpl) CreateParticipant(ctx context.Context, p *model.Participant) error {
+	err := s.db.Exec(ctx, `insert into meetings.web_meeting_participants (id, meeting_id, created_at)
+values (@id, @meeting_id, @created_at)`, pgx.NamedArgs{
+		"id":         p.Id,
+		"meeting_id": p.MeetingId,
+		"created_at": p.CreatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create participant: %w", err)
+	}
+
+	return nil
+}
+
+// GetParticipant returns the meeting participant, nil when not found.
+func (s *MeetingStoreImpl) GetParticipant(ctx context.Context, id string) (*model.Participant, error) {
+	var p model.Participant
+
+	err := s.db.Get(ctx, &p, `select id, meeting_id, created_at, revoked_at
+from meetings.web_meeting_participants
+where id = @id`, pgx.NamedArgs{"id": id})
+	if err != nil {
+		if s.db.IsNotFoundErr(err) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to get participant %s: %w", id, err)
+	}
+
+	return &p, nil
+}
+
+// RevokeParticipant stops accepting the credentials of the meeting participant, reports whether it is found.
+func (s *MeetingStoreImpl) RevokeParticipant(ctx context.Context, meetingId, id string, at int64) (bool, error) {
+	var found bool
+
+	err := s.db.Get(ctx, &found, `update meetings.web_meeting_participants
+set revoked_at = coalesce(revoked_at, @at)
+where id = @id
+    and meeting_id = @meeting_id
+returning true`, pgx.NamedArgs{
+		"id":         id,
+		"meeting_id": meetingId,
+		"at":         at,
+	})
+	if err != nil {
+		if s.db.IsNotFoundErr(err) {
+			return false, nil
+		}
+
+		return false, fmt.Errorf("failed to revoke participant: %w", err)
+	}
+
+	return true, nil
+}
+
+// ScheduleChatClose postpones the close of the meeting chat until the time.
+func (s *MeetingStoreImpl) ScheduleChatClose(ctx context.Context, id string, at int64) error {
 	err := s.db.Exec(ctx, `update meetings.web_meetings
-set satisfaction = @satisfaction
+set chat_close_at = @at
 where id = @id`, pgx.NamedArgs{
-		"id":           id,
-		"satisfaction": satisfaction,
+		"id": id,
+		"at": at,
 	})
+	if err != nil {
+		return fmt.Errorf("failed to schedule chat close: %w", err)
+	}
 
+	return nil
+}
+
+// TakeChatCloses clears and returns the meetings whose chat close time has come.
+func (s *MeetingStoreImpl) TakeChatCloses(ctx context.Context, now int64, limit int) ([]string, error) {
+	var res []string
+
+	err := s.db.Select(ctx, &res, `update meetings.web_meetings m
+set chat_close_at = null
+where m.id in (
+    select id
+    from meetings.web_meetings
+    where chat_close_at <= @now
+    order by chat_close_at
+    limit @limit
+    for update skip locked
+)
+returning m.id`, pgx.NamedArgs{
+		"now":   now,
+		"limit": limit,
+	})
 	if err != nil {
-		return fmt.Errorf("failed to set satisfaction: %w", err)
+		return nil, fmt.Errorf("failed to take chat closes: %w", err)
+	}
+
+	return res, nil
+}
+
+// GetChatPolicy returns the meeting chat policy of the domain, nil when it is not set.
+func (s *MeetingStoreImpl) GetChatPolicy(ctx context.Context, domainId int64) (*model.ChatPolicy, error) {
+	var p model.ChatPolicy
+
+	err := s.db.Get(ctx, &p, `select domain_id, mode, cause, delay_sec, schema_id, updated_at
+from meetings.chat_policies
+where domain_id = @domain_id`, pgx.NamedArgs{
+		"domain_id": domainId,
+	})
+	if err != nil {
+		if s.db.IsNotFoundErr(err) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to get chat policy: %w", err)
+	}
+
+	return &p, nil
+}
+
+// SetChatPolicy creates or replaces the meeting chat policy of the domain.
+func (s *MeetingStoreImpl) SetChatPolicy(ctx context.Context, p *model.ChatPolicy) error {
+	err := s.db.Exec(ctx, `insert into meetings.chat_policies (domain_id, mode, cause, delay_sec, schema_id, updated_at)
+values (@domain_id, @mode, @cause, @delay_sec, @schema_id, @updated_at)
+on conflict (domain_id) do update
+set mode = excluded.mode,
+    cause = excluded.cause,
+    delay_sec = excluded.delay_sec,
+    schema_id = excluded.schema_id,
+    updated_at = excluded.updated_at`, pgx.NamedArgs{
+		"domain_id":  p.DomainId,
+		"mode":       p.Mode,
+		"cause":      p.Cause,
+		"delay_sec":  p.DelaySec,
+		"schema_id":  p.SchemaId,
+		"updated_at": p.UpdatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set chat policy: %w", err)
+	}
+
+	return nil
+}
+
+// LinkCall remembers the call leg of the meeting, so later events without meeting variables can be resolved.
+func (s *MeetingStoreImpl) LinkCall(ctx context.Context, id, callId string, parentId *string, at int64) error {
+	err := s.db.Exec(ctx, `insert into meetings.web_meeting_calls (call_id, meeting_id, parent_id, created_at)
+values (@call_id, @id, @parent_id, @at)
+on conflict (call_id) do nothing`, pgx.NamedArgs{
+		"id":        id,
+		"call_id":   callId,
+		"parent_id": parentId,
+		"at":        at,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to link call: %w", err)
+	}
+
+	return nil
+}
+
+// EndCall stores the hangup of the call leg; a leg without the ringing event is linked here.
+func (s *MeetingStoreImpl) EndCall(ctx context.Context, id string, c *model.MeetingCall) error {
+	err := s.db.Exec(ctx, `insert into meetings.web_meeting_calls (call_id, meeting_id, parent_id, created_at, cause, talk_sec, ended_at)
+values (@call_id, @id, @parent_id, @started_at, @cause, @talk_sec, @ended_at)
+on conflict (call_id) do update
+set cause = excluded.cause,
+    talk_sec = excluded.talk_sec,
+    ended_at = excluded.ended_at`, pgx.NamedArgs{
+		"id":         id,
+		"call_id":    c.CallId,
+		"parent_id":  c.ParentId,
+		"started_at": c.StartedAt,
+		"cause":      c.Cause,
+		"talk_sec":   c.TalkSec,
+		"ended_at":   c.EndedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to end call: %w", err)
 	}
 
 	return nil
 }
 
-// TODO move to service
-func (s *MeetingStoreImpl) GetChatCloseInfo(ctx context.Context, id string) (*model.ChatCloseInfo, error) {
-	var res model.ChatCloseInfo
-	err := s.db.Get(ctx, &res, `select c.id as conversation_id, ch.id closer_id, ch.user_id as auth_user_id
-from chat.conversation c
-    left join chat.channel ch on ch.conversation_id = c.id
-where (c.props->>'wbt_meeting_id') = @id
-    and c.closed_at isnull
-order by c.created_at desc, ch.created_at
-limit 1`, pgx.NamedArgs{
+// GetCalls returns the call legs of the meeting in the start order.
+func (s *MeetingStoreImpl) GetCalls(ctx context.Context, id string) ([]*model.MeetingCall, error) {
+	var res []*model.MeetingCall
+
+	err := s.db.Select(ctx, &res, `select call_id, parent_id, cause, talk_sec, created_at as started_at, ended_at
+from meetings.web_meeting_calls
+where meeting_id = @id
+order by created_at, call_id`, pgx.NamedArgs{
 		"id": id,
 	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get meeting calls: %w", err)
+	}
 
+	return res, nil
+}
+
+// FindByCall returns the meeting id linked to the call.
+func (s *MeetingStoreImpl) FindByCall(ctx context.Context, callId string) (string, error) {
+	var id string
+
+	err := s.db.Get(ctx, &id, `select meeting_id
+from meetings.web_meeting_calls
+where call_id = @call_id`, pgx.NamedArgs{
+		"call_id": callId,
+	})
 	if err != nil {
 		if s.db.IsNotFoundErr(err) {
-			return nil, nil
+			return "", nil
 		}
 
-		return nil, err
+		return "", fmt.Errorf("failed to find meeting by call: %w", err)
+	}
+
+	return id, nil
+}
+
+// SetState moves the meeting to the state and stamps the first occurrence of the related event.
+// An ended meeting keeps its state, and a state event older than the last applied one only stamps
+// its occurrence; the finished leg has its own watermark, see SetCall.
+func (s *MeetingStoreImpl) SetState(ctx context.Context, id string, state model.MeetingState, eventAt int64) error {
+	err := s.db.Exec(ctx, `update meetings.web_meetings
+set state = case when coalesce(state_event_at, 0) <= @event_at then @state else state end,
+    state_at = case when coalesce(state_event_at, 0) <= @event_at then @at else state_at end,
+    state_event_at = greatest(coalesce(state_event_at, 0), @event_at),
+    ringing_at = case when @state = 'customer_waiting' then coalesce(ringing_at, @at) else ringing_at end,
+    answered_at = case when @state = 'agent_connected' then coalesce(answered_at, @at) else answered_at end,
+    ended_at = case when @state = 'ended' then coalesce(ended_at, @at) else ended_at end
+where id = @id
+    and state <> 'ended'`, pgx.NamedArgs{
+		"id":       id,
+		"state":    state,
+		"at":       eventAt / 1000,
+		"event_at": eventAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set state: %w", err)
+	}
+
+	return nil
+}
+
+// End moves the meeting to the ended state and expires its link.
+func (s *MeetingStoreImpl) End(ctx context.Context, id string, at int64) error {
+	err := s.db.Exec(ctx, `update meetings.web_meetings
+set state = 'ended',
+    state_at = @at,
+    ended_at = coalesce(ended_at, @at),
+    expires_at = least(expires_at, @at)
+where id = @id`, pgx.NamedArgs{
+		"id": id,
+		"at": at,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to end meeting: %w", err)
+	}
+
+	return nil
+}
+
+// SetBridged marks the meeting as connected with an agent at the bridge time. The bridge is always
+// stamped, the state only when no newer state event has been applied.
+func (s *MeetingStoreImpl) SetBridged(ctx context.Context, id string, eventAt int64) error {
+	err := s.db.Exec(ctx, `update meetings.web_meetings
+set state = case when coalesce(state_event_at, 0) <= @event_at then 'agent_connected' else state end,
+    state_at = case when coalesce(state_event_at, 0) <= @event_at then @at else state_at end,
+    state_event_at = greatest(coalesce(state_event_at, 0), @event_at),
+    answered_at = coalesce(answered_at, @at),
+    bridged_at = coalesce(bridged_at, @at)
+where id = @id
+    and state <> 'ended'`, pgx.NamedArgs{
+		"id":       id,
+		"at":       eventAt / 1000,
+		"event_at": eventAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set bridged: %w", err)
 	}
 
-	return &res, nil
+	return nil
+}
+
+// SetOutcome stores the outcome classified from the finished call legs.
+func (s *MeetingStoreImpl) SetOutcome(ctx context.Context, id string, outcome model.MeetingOutcome) error {
+	err := s.db.Exec(ctx, `update meetings.web_meetings
+set outcome = @outcome
+where id = @id`, pgx.NamedArgs{
+		"id":      id,
+		"outcome": outcome,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set outcome: %w", err)
+	}
+
+	return nil
+}
+
+// ClaimCallEvent records the call event in the inbox for processing, or claims again the event
+// whose claim lease is over. Returns false when the event has already been processed, e.g. the broker
+// redelivered the message, and model.ErrCallEventClaimed while another claim of the event is held.
+func (s *MeetingStoreImpl) ClaimCallEvent(ctx context.Context, id, callId, event string, eventAt int64) (bool, error) {
+	var res struct {
+		Claimed   bool
+		Processed bool
+	}
+
+	now := time.Now()
+	// the select sees the inbox before the insert, i.e. the event claimed or processed already
+	err := s.db.Get(ctx, &res, `with claim as (
+    insert into meetings.web_meeting_call_inbox as i (call_id, event, event_at, meeting_id, claimed_at)
+    values (@call_id, @event, @event_at, @id, @now)
+    on conflict (call_id, event, event_at) do update
+        set claimed_at = excluded.claimed_at
+    where i.processed_at is null
+        and i.claimed_at < @lease_before
+    returning true as claimed
+)
+select coalesce((select claimed from claim), false) as claimed,
+       exists(select 1
+              from meetings.web_meeting_call_inbox
+              where call_id = @call_id
+                  and event = @event
+                  and event_at = @event_at
+                  and processed_at is not null) as processed`, pgx.NamedArgs{
+		"id":           id,
+		"call_id":      callId,
+		"event":        event,
+		"event_at":     eventAt,
+		"now":          now.Unix(),
+		"lease_before": now.Add(-callClaimLease).Unix(),
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to claim call event: %w", err)
+	}
+
+	if !res.Claimed && !res.Processed {
+		return false, model.ErrCallEventClaimed
+	}
+
+	return res.Claimed, nil
+}
+
+// CompleteCallEvent marks the claimed call event processed, so its redeliveries are skipped.
+func (s *MeetingStoreImpl) CompleteCallEvent(ctx context.Context, callId, event string, eventAt int64) error {
+	err := s.db.Exec(ctx, `update meetings.web_meeting_call_inbox
+set processed_at = @now
+where call_id = @call_id
+    and event = @event
+    and event_at = @event_at`, pgx.NamedArgs{
+		"call_id":  callId,
+		"event":    event,
+		"event_at": eventAt,
+		"now":      time.Now().Unix(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to complete call event: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteCallInbox removes the call events processed, or claimed and never processed, before the time;
+// their redeliveries are long gone.
+func (s *MeetingStoreImpl) DeleteCallInbox(ctx context.Context, before int64) error {
+	err := s.db.Exec(ctx, `delete from meetings.web_meeting_call_inbox
+where processed_at < @before
+    or (processed_at is null and claimed_at < @before)`, pgx.NamedArgs{"before": before})
+	if err != nil {
+		return fmt.Errorf("failed to delete call inbox: %w", err)
+	}
+
+	return nil
+}
+
+// ReleaseCallEvent removes the claimed event from the inbox, so its redelivery is processed again.
+func (s *MeetingStoreImpl) ReleaseCallEvent(ctx context.Context, callId, event string, eventAt int64) error {
+	err := s.db.Exec(ctx, `delete from meetings.web_meeting_call_inbox
+where call_id = @call_id
+    and event = @event
+    and event_at = @event_at
+    and processed_at is null`, pgx.NamedArgs{
+		"call_id":  callId,
+		"event":    event,
+		"event_at": eventAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to release call event: %w", err)
+	}
+
+	return nil
+}
+
+func (s *MeetingStoreImpl) SetSatisfaction(ctx context.Context, id string, satisfaction string) error {
+	err := s.db.Exec(ctx, `update meetings.web_meetings
+set satisfaction = @satisfaction
+where id = @id`, pgx.NamedArgs{
+		"id":           id,
+		"satisfaction": satisfaction,
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to set satisfaction: %w", err)
+	}
+
+	return nil
 }
 
 func (s *MeetingStoreImpl) cleanup(ctx context.Context) error {
@@ -148,9 +664,11 @@ func (s *MeetingStoreImpl) cleanup(ctx context.Context) error {
 			return nil
 		case <-timer.C:
 			now := time.Now().Unix()
-			err := s.DeleteExpires(ctx, now)
-			if err != nil {
-				s.log.Error("failed to delete expired meetings", wlog.Err(err))
+			// TODO expired meetings are kept for the details and the usage until a retention is set
+			// err := s.DeleteExpires(ctx, now)
+
+			if err := s.DeleteCallInbox(ctx, now-int64(callInboxRetention.Seconds())); err != nil {
+				s.log.Error("failed to delete call inbox", wlog.Err(err))
 			}
 		}
 	}
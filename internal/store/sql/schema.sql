@@ -10,4 +10,138 @@ CREATE TABLE IF NOT EXISTS meetings.web_meetings (
 );
 
 create index web_meetings_expires_at_index
-    on meetings.web_meetings (expires_at);
\ No newline at end of file
+    on meetings.web_meetings (expires_at);
+
+CREATE TABLE IF NOT EXISTS meetings.webhooks (
+    id BIGSERIAL PRIMARY KEY,
+    domain_id BIGINT NOT NULL,
+    url TEXT NOT NULL,
+    events TEXT[] NOT NULL DEFAULT '{}',
+    secret TEXT NOT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    failures INT NOT NULL DEFAULT 0,
+    created_at BIGINT NOT NULL,
+    updated_at BIGINT NOT NULL,
+    disabled_at BIGINT
+);
+
+create index webhooks_domain_id_index
+    on meetings.webhooks (domain_id);
+
+CREATE TABLE IF NOT EXISTS meetings.webhook_deliveries (
+    id BIGSERIAL PRIMARY KEY,
+    webhook_id BIGINT NOT NULL REFERENCES meetings.webhooks (id) ON DELETE CASCADE,
+    event_id TEXT NOT NULL,
+    event TEXT NOT NULL,
+    attempt INT NOT NULL,
+    status_code INT NOT NULL DEFAULT 0,
+    error TEXT,
+    duration_ms BIGINT NOT NULL DEFAULT 0,
+    success BOOLEAN NOT NULL,
+    created_at BIGINT NOT NULL
+);
+
+create index webhook_deliveries_webhook_id_index
+    on meetings.webhook_deliveries (webhook_id, id desc);
+
+ALTER TABLE meetings.web_meetings
+    ADD COLUMN IF NOT EXISTS state TEXT NOT NULL DEFAULT 'created',
+    ADD COLUMN IF NOT EXISTS state_at BIGINT,
+    ADD COLUMN IF NOT EXISTS ringing_at BIGINT,
+    ADD COLUMN IF NOT EXISTS answered_at BIGINT,
+    ADD COLUMN IF NOT EXISTS bridged_at BIGINT,
+    ADD COLUMN IF NOT EXISTS ended_at BIGINT;
+
+CREATE TABLE IF NOT EXISTS meetings.web_meeting_calls (
+    call_id TEXT PRIMARY KEY,
+    meeting_id TEXT NOT NULL REFERENCES meetings.web_meetings (id) ON DELETE CASCADE,
+    parent_id TEXT,
+    created_at BIGINT NOT NULL
+);
+
+create index web_meeting_calls_meeting_id_index
+    on meetings.web_meeting_calls (meeting_id);
+
+ALTER TABLE meetings.web_meetings
+    ADD COLUMN IF NOT EXISTS call_event_at BIGINT;
+
+CREATE TABLE IF NOT EXISTS meetings.web_meeting_call_inbox (
+    call_id TEXT NOT NULL,
+    event TEXT NOT NULL,
+    event_at BIGINT NOT NULL,
+    meeting_id TEXT NOT NULL REFERENCES meetings.web_meetings (id) ON DELETE CASCADE,
+    processed_at BIGINT NOT NULL,
+    PRIMARY KEY (call_id, event, event_at)
+);
+
+create index web_meeting_call_inbox_meeting_id_index
+    on meetings.web_meeting_call_inbox (meeting_id);
+
+ALTER TABLE meetings.web_meeting_calls
+    ADD COLUMN IF NOT EXISTS cause TEXT,
+    ADD COLUMN IF NOT EXISTS talk_sec INT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS ended_at BIGINT;
+
+ALTER TABLE meetings.web_meetings
+    ADD COLUMN IF NOT EXISTS outcome TEXT;
+
+ALTER TABLE meetings.web_meetings
+    ADD COLUMN IF NOT EXISTS conversation_id TEXT;
+
+ALTER TABLE meetings.web_meetings
+    ADD COLUMN IF NOT EXISTS chat_channel_id TEXT;
+
+ALTER TABLE meetings.web_meetings
+    ADD COLUMN IF NOT EXISTS opened_at BIGINT;
+
+ALTER TABLE meetings.web_meetings
+    ADD COLUMN IF NOT EXISTS chat_close_at BIGINT;
+
+ALTER TABLE meetings.web_meetings
+    ADD COLUMN IF NOT EXISTS contact_id TEXT;
+
+create index if not exists web_meetings_chat_close_at_index
+    on meetings.web_meetings (chat_close_at)
+    where chat_close_at is not null;
+
+CREATE TABLE IF NOT EXISTS meetings.chat_policies (
+    domain_id BIGINT PRIMARY KEY,
+    mode TEXT NOT NULL,
+    cause TEXT NOT NULL DEFAULT 'no_cause',
+    delay_sec INT NOT NULL DEFAULT 0,
+    schema_id BIGINT NOT NULL DEFAULT 0,
+    updated_at BIGINT NOT NULL
+);
+
+create index if not exists web_meetings_domain_id_active_index
+    on meetings.web_meetings (domain_id, expires_at)
+    where ended_at is null;
+
+CREATE TABLE IF NOT EXISTS meetings.daily_usage (
+    domain_id BIGINT NOT NULL,
+    day BIGINT NOT NULL,
+    created INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (domain_id, day)
+);
+
+CREATE TABLE IF NOT EXISTS meetings.web_meeting_participants (
+    id TEXT PRIMARY KEY,
+    meeting_id TEXT NOT NULL REFERENCES meetings.web_meetings (id) ON DELETE CASCADE,
+    created_at BIGINT NOT NULL,
+    revoked_at BIGINT
+);
+
+create index if not exists web_meeting_participants_meeting_id_index
+    on meetings.web_meeting_participants (meeting_id);
+
+create index if not exists web_meeting_call_inbox_processed_at_index
+    on meetings.web_meeting_call_inbox (processed_at);
+
+ALTER TABLE meetings.web_meetings
+    ADD COLUMN IF NOT EXISTS state_event_at BIGINT;
+
+ALTER TABLE meetings.web_meeting_call_inbox
+    ADD COLUMN IF NOT EXISTS claimed_at BIGINT;
+
+ALTER TABLE meetings.web_meeting_call_inbox
+    ALTER COLUMN processed_at DROP NOT NULL;
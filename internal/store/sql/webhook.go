@@ -0,0 +1,231 @@
+package sql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/webitel/wlog"
+
+	"github.com/webitel/web-meeting-backend/infra/sql"
+	"github.com/webitel/web-meeting-backend/internal/model"
+)
+
+type WebhookStoreImpl struct {
+	log *wlog.Logger
+	db  sql.Store
+}
+
+func NewWebhookStore(db sql.Store, log *wlog.Logger) *WebhookStoreImpl {
+	return &WebhookStoreImpl{
+		log: log,
+		db:  db,
+	}
+}
+
+func (s *WebhookStoreImpl) Create(ctx context.Context, w *model.Webhook) error {
+	err := s.db.Get(ctx, &w.Id, `
+		INSERT INTO meetings.webhooks (domain_id, url, events, secret, enabled, created_at, updated_at)
+		VALUES (@domain_id, @url, @events, @secret, @enabled, @created_at, @updated_at)
+		RETURNING id
+	`, pgx.NamedArgs{
+		"domain_id":  w.DomainId,
+		"url":        w.Url,
+		"events":     w.Events,
+		"secret":     w.Secret,
+		"enabled":    w.Enabled,
+		"created_at": w.CreatedAt,
+		"updated_at": w.UpdatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create webhook: %w", err)
+	}
+
+	return nil
+}
+
+func (s *WebhookStoreImpl) Get(ctx context.Context, domainId, id int64) (*model.Webhook, error) {
+	var w model.Webhook
+
+	err := s.db.Get(ctx, &w, `
+		SELECT id, domain_id, url, events, secret, enabled, failures, created_at, updated_at, disabled_at
+		FROM meetings.webhooks
+		WHERE id = @id AND domain_id = @domain_id
+	`, pgx.NamedArgs{
+		"id":        id,
+		"domain_id": domainId,
+	})
+	if err != nil {
+		if s.db.IsNotFoundErr(err) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to get webhook %d: %w", id, err)
+	}
+
+	return &w, nil
+}
+
+func (s *WebhookStoreImpl) List(ctx context.Context, domainId int64, page, size int) ([]*model.Webhook, error) {
+	var res []*model.Webhook
+
+	err := s.db.Select(ctx, &res, `
+		SELECT id, domain_id, url, events, secret, enabled, failures, created_at, updated_at, disabled_at
+		FROM meetings.webhooks
+		WHERE domain_id = @domain_id
+		ORDER BY id
+		LIMIT @limit OFFSET @offset
+	`, pgx.NamedArgs{
+		"domain_id": domainId,
+		"limit":     size + 1,
+		"offset":    (page - 1) * size,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+
+	return res, nil
+}
+
+// ListSubscribed returns enabled webhooks of the domain subscribed to the event.
+func (s *WebhookStoreImpl) ListSubscribed(ctx context.Context, domainId int64, event string) ([]*model.Webhook, error) {
+	var res []*model.Webhook
+
+	err := s.db.Select(ctx, &res, `
+		SELECT id, domain_id, url, events, secret, enabled, failures, created_at, updated_at, disabled_at
+		FROM meetings.webhooks
+		WHERE domain_id = @domain_id
+		  AND enabled
+		  AND @event = ANY (events)
+	`, pgx.NamedArgs{
+		"domain_id": domainId,
+		"event":     event,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list subscribed webhooks: %w", err)
+	}
+
+	return res, nil
+}
+
+func (s *WebhookStoreImpl) Update(ctx context.Context, w *model.Webhook) error {
+	err := s.db.Exec(ctx, `update meetings.webhooks
+set url = @url,
+    events = @events,
+    secret = @secret,
+    enabled = @enabled,
+    failures = case when @enabled and not enabled then 0 else failures end,
+    disabled_at = case when @enabled then null else disabled_at end,
+    updated_at = @updated_at
+where id = @id and domain_id = @domain_id`, pgx.NamedArgs{
+		"id":         w.Id,
+		"domain_id":  w.DomainId,
+		"url":        w.Url,
+		"events":     w.Events,
+		"secret":     w.Secret,
+		"enabled":    w.Enabled,
+		"updated_at": w.UpdatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update webhook: %w", err)
+	}
+
+	return nil
+}
+
+func (s *WebhookStoreImpl) Delete(ctx context.Context, domainId, id int64) error {
+	err := s.db.Exec(ctx, `DELETE FROM meetings.webhooks WHERE id = @id AND domain_id = @domain_id`, pgx.NamedArgs{
+		"id":        id,
+		"domain_id": domainId,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+
+	return nil
+}
+
+func (s *WebhookStoreImpl) LogDelivery(ctx context.Context, d *model.WebhookDelivery) error {
+	err := s.db.Exec(ctx, `
+		INSERT INTO meetings.webhook_deliveries (webhook_id, event_id, event, attempt, status_code, error, duration_ms, success, created_at)
+		VALUES (@webhook_id, @event_id, @event, @attempt, @status_code, @error, @duration_ms, @success, @created_at)
+	`, pgx.NamedArgs{
+		"webhook_id":  d.WebhookId,
+		"event_id":    d.EventId,
+		"event":       d.Event,
+		"attempt":     d.Attempt,
+		"status_code": d.StatusCode,
+		"error":       d.Error,
+		"duration_ms": d.DurationMs,
+		"success":     d.Success,
+		"created_at":  d.CreatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to log webhook delivery: %w", err)
+	}
+
+	return nil
+}
+
+func (s *WebhookStoreImpl) ListDeliveries(ctx context.Context, domainId, webhookId int64, page, size int) ([]*model.WebhookDelivery, error) {
+	var res []*model.WebhookDelivery
+
+	err := s.db.Select(ctx, &res, `
+		SELECT d.id, d.webhook_id, d.event_id, d.event, d.attempt, d.status_code, d.error, d.duration_ms, d.success, d.created_at
+		FROM meetings.webhook_deliveries d
+		    INNER JOIN meetings.webhooks w ON w.id = d.webhook_id
+		WHERE d.webhook_id = @webhook_id AND w.domain_id = @domain_id
+		ORDER BY d.id DESC
+		LIMIT @limit OFFSET @offset
+	`, pgx.NamedArgs{
+		"webhook_id": webhookId,
+		"domain_id":  domainId,
+		"limit":      size + 1,
+		"offset":     (page - 1) * size,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+
+	return res, nil
+}
+
+// RegisterFailure increments the consecutive failure counter and disables the webhook
+// once the threshold is reached. Returns true when the webhook has been disabled.
+func (s *WebhookStoreImpl) RegisterFailure(ctx context.Context, id int64, threshold int, now int64) (bool, error) {
+	var disabled bool
+
+	err := s.db.Get(ctx, &disabled, `update meetings.webhooks
+set failures = failures + 1,
+    enabled = case when failures + 1 >= @threshold then false else enabled end,
+    disabled_at = case when failures + 1 >= @threshold then @now else disabled_at end
+where id = @id
+returning not enabled`, pgx.NamedArgs{
+		"id":        id,
+		"threshold": threshold,
+		"now":       now,
+	})
+	if err != nil {
+		if s.db.IsNotFoundErr(err) {
+			return false, nil
+		}
+
+		return false, fmt.Errorf("failed to register webhook failure: %w", err)
+	}
+
+	return disabled, nil
+}
+
+func (s *WebhookStoreImpl) ResetFailures(ctx context.Context, id int64) error {
+	err := s.db.Exec(ctx, `update meetings.webhooks
+set failures = 0
+where id = @id and failures > 0`, pgx.NamedArgs{
+		"id": id,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to reset webhook failures: %w", err)
+	}
+
+	return nil
+}